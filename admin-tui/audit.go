@@ -0,0 +1,181 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// maxAuditLog caps the in-memory audit tail shown in the sidebar; older
+// entries scroll off rather than growing the slice without bound.
+const maxAuditLog = 20
+
+// auditEventMsg is one entry read from GET /api/v1/admin/audit/stream,
+// e.g. an operator terminating a session or editing a user's quota.
+type auditEventMsg struct {
+	Actor  string    `json:"actor"`
+	Action string    `json:"action"`
+	Target string    `json:"target"`
+	Result string    `json:"result"` // "success", "denied", "error"
+	At     time.Time `json:"at"`
+}
+
+type auditConnectedMsg struct{}
+type auditDisconnectedMsg struct {
+	err       error
+	permanent bool
+}
+
+// listenAuditStream turns the model's auditSub channel into a bubbletea
+// command; re-issue it after handling every message to keep listening.
+func (m model) listenAuditStream() tea.Cmd {
+	sub := m.auditSub
+	return func() tea.Msg {
+		return <-sub
+	}
+}
+
+// startAuditStream launches the audit SSE reader goroutine in the
+// background, following the same connect/backoff/permanent-404 shape as
+// startStream in stream.go.
+func (m model) startAuditStream() tea.Cmd {
+	sub := m.auditSub
+	apiURL := m.apiURL
+	token := m.token
+
+	go func() {
+		backoff := sseMinBackoff
+		for {
+			err := auditStreamOnce(apiURL, token, sub)
+			if err == errStreamNotFound {
+				sub <- auditDisconnectedMsg{err: err, permanent: true}
+				return
+			}
+
+			sub <- auditDisconnectedMsg{err: err}
+			time.Sleep(backoff)
+			backoff *= 2
+			if backoff > sseMaxBackoff {
+				backoff = sseMaxBackoff
+			}
+		}
+	}()
+
+	return m.listenAuditStream()
+}
+
+func auditStreamOnce(apiURL, token string, sub chan tea.Msg) error {
+	req, err := http.NewRequestWithContext(context.Background(), "GET", apiURL+"/api/v1/admin/audit/stream", nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	if token != "" {
+		req.Header.Set("Authorization", "ApiKey "+token)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return errStreamNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("audit stream failed (status %d)", resp.StatusCode)
+	}
+
+	sub <- auditConnectedMsg{}
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var eventType string
+	var dataLines []string
+
+	flush := func() {
+		if eventType != "audit.action" || len(dataLines) == 0 {
+			return
+		}
+		var evt auditEventMsg
+		if err := json.Unmarshal([]byte(strings.Join(dataLines, "\n")), &evt); err == nil {
+			sub <- evt
+		}
+		eventType = ""
+		dataLines = nil
+	}
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			flush()
+		case strings.HasPrefix(line, "event:"):
+			eventType = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			dataLines = append(dataLines, strings.TrimSpace(strings.TrimPrefix(line, "data:")))
+		}
+	}
+	flush()
+
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	return fmt.Errorf("stream closed by server")
+}
+
+func auditStatusLabel(status string) string {
+	switch status {
+	case "live":
+		return successStyle.Render("live")
+	case "disconnected":
+		return errorStyle.Render("disconnected")
+	default:
+		return helpStyle.Render("connecting")
+	}
+}
+
+// renderAuditPane draws the last N audit events as a vertical sidebar,
+// color-coded by result, so an operator always sees who else is acting
+// on the same cluster and the consequence of their own keystrokes.
+func renderAuditPane(log []auditEventMsg, status string, width int) string {
+	var b strings.Builder
+	b.WriteString(helpStyle.Render(strings.Repeat("─", width)))
+	b.WriteString("\n")
+	b.WriteString(lipgloss.NewStyle().Bold(true).Render("Audit Log"))
+	b.WriteString(" (")
+	b.WriteString(auditStatusLabel(status))
+	b.WriteString(")\n\n")
+
+	if len(log) == 0 {
+		b.WriteString(helpStyle.Render("(no recent admin actions)"))
+		return b.String()
+	}
+
+	for _, evt := range log {
+		style := lipgloss.NewStyle()
+		switch evt.Result {
+		case "success":
+			style = successStyle
+		case "denied", "error":
+			style = errorStyle
+		default:
+			style = warningStyle
+		}
+		line := fmt.Sprintf("%s %s %s -> %s [%s]",
+			evt.At.Format("15:04:05"), evt.Actor, evt.Action, evt.Target, evt.Result)
+		b.WriteString(style.Render(line))
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
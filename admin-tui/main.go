@@ -18,6 +18,7 @@ import (
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
@@ -94,9 +95,22 @@ type ReplayEntry struct {
 }
 
 type UserEntry struct {
-	UserID    string `json:"user_id"`
-	Enabled   bool   `json:"enabled"`
-	Languages int    `json:"allowed_languages_count"`
+	UserID           string    `json:"user_id"`
+	Enabled          bool      `json:"enabled"`
+	Languages        int       `json:"allowed_languages_count"`
+	LastSeen         time.Time `json:"last_seen"`
+	QuotaUsedPercent float64   `json:"quota_used_percent"`
+}
+
+// UserDetail is the full editable record for a user, fetched when
+// opening the edit form and submitted back as a JSON-patch style diff.
+type UserDetail struct {
+	UserID           string   `json:"user_id"`
+	Enabled          bool     `json:"enabled"`
+	AllowedLanguages []string `json:"allowed_languages"`
+	CPUQuota         float64  `json:"cpu_quota"`
+	MemoryQuotaMB    float64  `json:"memory_quota_mb"`
+	ExecQuota        int      `json:"exec_quota"`
 }
 
 // Key bindings
@@ -109,18 +123,21 @@ type keyMap struct {
 	Back     key.Binding
 	Replays  key.Binding
 	Users    key.Binding
+	Metrics  key.Binding
+	Select   key.Binding
+	BulkKill key.Binding
 	Quit     key.Binding
 	Help     key.Binding
 }
 
 func (k keyMap) ShortHelp() []key.Binding {
-	return []key.Binding{k.Refresh, k.Details, k.Replays, k.Users, k.Quit}
+	return []key.Binding{k.Refresh, k.Details, k.Replays, k.Users, k.Metrics, k.Quit}
 }
 
 func (k keyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
 		{k.Up, k.Down, k.Refresh},
-		{k.Kill, k.Details, k.Back},
+		{k.Kill, k.Select, k.BulkKill, k.Details, k.Back},
 		{k.Help, k.Quit},
 	}
 }
@@ -158,6 +175,18 @@ var keys = keyMap{
 		key.WithKeys("u"),
 		key.WithHelp("u", "users"),
 	),
+	Metrics: key.NewBinding(
+		key.WithKeys("m"),
+		key.WithHelp("m", "metrics"),
+	),
+	Select: key.NewBinding(
+		key.WithKeys(" "),
+		key.WithHelp("space", "select"),
+	),
+	BulkKill: key.NewBinding(
+		key.WithKeys("X"),
+		key.WithHelp("X", "terminate selected"),
+	),
 	Quit: key.NewBinding(
 		key.WithKeys("q", "ctrl+c"),
 		key.WithHelp("q", "quit"),
@@ -174,7 +203,6 @@ type healthMsg HealthResponse
 type sessionsMsg []SessionSummary
 type statsMsg SystemStats
 type errorMsg struct{ err error }
-type terminateMsg struct{ success bool; userID string }
 
 // Model
 type model struct {
@@ -197,13 +225,34 @@ type model struct {
 	width       int
 	height      int
 
-	view        string // "main", "details", "replays", "users"
-	selected    *SessionSummary
-	replays     []ReplayEntry
-	users       []UserEntry
+	view         string // "main", "details", "replays", "replay_playback", "users", "user_edit", "user_delete_confirm", "user_import_preview", "terminate_confirm"
+	selected     *SessionSummary
+	replays      []ReplayEntry
+	replaysTable table.Model
+	replayFilter replayFilter
+	playback     *replayPlayback
+
+	users             []UserEntry
+	usersTable        table.Model
+	userEdit          *userEditForm
+	userDeleteConfirm *userDeleteConfirm
+	userImportPreview *userImportPreview
+
+	selectedUsers    map[string]bool
+	terminateConfirm *terminateConfirm
+
+	streamSub      chan tea.Msg
+	streamStatus   string // "connecting", "live", "polling"
+	securityEvents []securityEventMsg
+
+	auditSub    chan tea.Msg
+	auditStatus string // "connecting", "live", "disconnected"
+	auditLog    []auditEventMsg
+
+	metrics metricsState
 }
 
-func initialModel(apiURL, token string) model {
+func initialModel(apiURL, token, promURL string) model {
 	columns := []table.Column{
 		{Title: "User", Width: 15},
 		{Title: "Status", Width: 10},
@@ -232,19 +281,56 @@ func initialModel(apiURL, token string) model {
 		Bold(false)
 	t.SetStyles(s)
 
+	replayColumns := []table.Column{
+		{Title: "Execution", Width: 20},
+		{Title: "User", Width: 15},
+		{Title: "Language", Width: 10},
+		{Title: "Status", Width: 10},
+		{Title: "Timestamp", Width: 20},
+	}
+	rt := table.New(
+		table.WithColumns(replayColumns),
+		table.WithFocused(true),
+		table.WithHeight(10),
+	)
+	rt.SetStyles(s)
+
+	userColumns := []table.Column{
+		{Title: "User", Width: 15},
+		{Title: "Enabled", Width: 8},
+		{Title: "Languages", Width: 10},
+		{Title: "Last Seen", Width: 20},
+		{Title: "Quota Used", Width: 10},
+	}
+	ut := table.New(
+		table.WithColumns(userColumns),
+		table.WithFocused(true),
+		table.WithHeight(10),
+	)
+	ut.SetStyles(s)
+
 	sp := spinner.New()
 	sp.Spinner = spinner.Dot
 	sp.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("205"))
 
 	return model{
-		apiURL:  apiURL,
-		token:   token,
-		table:   t,
-		spinner: sp,
-		help:    help.New(),
-		keys:    keys,
-		loading: true,
-		view:    "main",
+		apiURL:        apiURL,
+		token:         token,
+		table:         t,
+		replaysTable:  rt,
+		replayFilter:  newReplayFilter(),
+		usersTable:    ut,
+		spinner:       sp,
+		help:          help.New(),
+		keys:          keys,
+		loading:       true,
+		view:          "main",
+		streamSub:     make(chan tea.Msg, 16),
+		streamStatus:  "connecting",
+		selectedUsers: make(map[string]bool),
+		auditSub:      make(chan tea.Msg, 16),
+		auditStatus:   "connecting",
+		metrics:       newMetricsState(promURL),
 	}
 }
 
@@ -254,6 +340,8 @@ func (m model) Init() tea.Cmd {
 		m.fetchHealth(),
 		m.fetchSessions(),
 		m.fetchStats(),
+		m.startStream(),
+		m.startAuditStream(),
 		tea.Every(5*time.Second, func(t time.Time) tea.Msg {
 			return tickMsg(t)
 		}),
@@ -265,23 +353,77 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if m.view == "replays" || m.view == "replay_playback" {
+			if newModel, cmd, handled := m.handleReplaysKey(msg); handled {
+				return newModel, cmd
+			}
+		}
+		if m.view == "metrics" {
+			if newModel, cmd, handled := m.handleMetricsKey(msg); handled {
+				return newModel, cmd
+			}
+		}
+		if m.view == "users" || m.view == "user_edit" || m.view == "user_delete_confirm" || m.view == "user_import_preview" {
+			if newModel, cmd, handled := m.handleUsersKey(msg); handled {
+				return newModel, cmd
+			}
+		}
+		if m.view == "terminate_confirm" {
+			if newModel, cmd, handled := m.handleTerminateKey(msg); handled {
+				return newModel, cmd
+			}
+		}
+
 		switch {
 		case key.Matches(msg, m.keys.Quit):
 			return m, tea.Quit
 		case key.Matches(msg, m.keys.Refresh):
 			m.loading = true
-			return m, tea.Batch(
-				m.fetchHealth(),
-				m.fetchSessions(),
-				m.fetchStats(),
-			)
+			switch m.view {
+			case "replays", "replay_playback":
+				return m, m.fetchReplays()
+			case "users":
+				return m, m.fetchUsers()
+			default:
+				return m, tea.Batch(
+					m.fetchHealth(),
+					m.fetchSessions(),
+					m.fetchStats(),
+				)
+			}
 		case key.Matches(msg, m.keys.Kill):
 			if m.view == "main" && len(m.sessions) > 0 {
 				idx := m.table.Cursor()
 				if idx < len(m.sessions) {
-					return m, m.terminateSession(m.sessions[idx].UserID)
+					m.terminateConfirm = newTerminateConfirm(m.sessions, []string{m.sessions[idx].UserID})
+					m.view = "terminate_confirm"
+					return m, textinput.Blink
 				}
 			}
+		case key.Matches(msg, m.keys.Select):
+			if m.view == "main" && len(m.sessions) > 0 {
+				idx := m.table.Cursor()
+				if idx < len(m.sessions) {
+					userID := m.sessions[idx].UserID
+					if m.selectedUsers[userID] {
+						delete(m.selectedUsers, userID)
+					} else {
+						m.selectedUsers[userID] = true
+					}
+					m.updateTable()
+				}
+			}
+		case key.Matches(msg, m.keys.BulkKill):
+			if m.view == "main" && len(m.selectedUsers) > 0 {
+				userIDs := make([]string, 0, len(m.selectedUsers))
+				for id := range m.selectedUsers {
+					userIDs = append(userIDs, id)
+				}
+				sort.Strings(userIDs)
+				m.terminateConfirm = newTerminateConfirm(m.sessions, userIDs)
+				m.view = "terminate_confirm"
+				return m, textinput.Blink
+			}
 		case key.Matches(msg, m.keys.Details):
 			if m.view == "main" && len(m.sessions) > 0 {
 				idx := m.table.Cursor()
@@ -291,17 +433,42 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 			}
 		case key.Matches(msg, m.keys.Back):
-			if m.view != "main" {
+			switch m.view {
+			case "replay_playback":
+				m.view = "replays"
+				m.playback = nil
+			case "user_edit":
+				m.view = "users"
+				m.userEdit = nil
+			case "user_delete_confirm":
+				m.view = "users"
+				m.userDeleteConfirm = nil
+			case "user_import_preview":
+				m.view = "users"
+				m.userImportPreview = nil
+			case "terminate_confirm":
+				m.view = "main"
+				m.terminateConfirm = nil
+			case "main":
+			default:
 				m.view = "main"
 				m.selected = nil
 			}
 		case key.Matches(msg, m.keys.Replays):
-			if m.view == "main" {
+			if m.view != "replays" {
 				m.view = "replays"
+				return m, m.fetchReplays()
 			}
 		case key.Matches(msg, m.keys.Users):
-			if m.view == "main" {
+			if m.view != "users" {
 				m.view = "users"
+				return m, m.fetchUsers()
+			}
+		case key.Matches(msg, m.keys.Metrics):
+			if m.view != "metrics" {
+				m.view = "metrics"
+				m.metrics.input.Focus()
+				return m, textinput.Blink
 			}
 		}
 
@@ -309,8 +476,15 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.width = msg.Width
 		m.height = msg.Height
 		m.table.SetHeight(msg.Height - 15)
+		m.replaysTable.SetHeight(msg.Height - 15)
+		m.usersTable.SetHeight(msg.Height - 15)
 
 	case tickMsg:
+		if m.streamStatus == "live" {
+			// The SSE stream is keeping sessions/stats current; stop
+			// the polling fallback loop until it disconnects.
+			break
+		}
 		return m, tea.Batch(
 			m.fetchSessions(),
 			m.fetchStats(),
@@ -319,6 +493,44 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}),
 		)
 
+	case sseConnectedMsg:
+		m.streamStatus = "live"
+		cmds = append(cmds, m.listenStream())
+
+	case sseDisconnectedMsg:
+		wasLive := m.streamStatus == "live"
+		m.streamStatus = "polling"
+		if !msg.permanent {
+			cmds = append(cmds, m.listenStream())
+		}
+		if wasLive {
+			cmds = append(cmds, tea.Every(5*time.Second, func(t time.Time) tea.Msg {
+				return tickMsg(t)
+			}))
+		}
+
+	case sessionEventMsg:
+		m.applySessionEvent(msg)
+		m.lastUpdate = time.Now()
+		cmds = append(cmds, m.listenStream())
+
+	case statsDeltaMsg:
+		s := SystemStats(msg)
+		m.stats = &s
+		cmds = append(cmds, m.listenStream())
+
+	case securityEventMsg:
+		m.securityEvents = append([]securityEventMsg{msg}, m.securityEvents...)
+		if len(m.securityEvents) > 50 {
+			m.securityEvents = m.securityEvents[:50]
+		}
+		cmds = append(cmds, m.listenStream())
+
+	case promResultMsg:
+		m.metrics.loading = false
+		m.metrics.result = msg.result
+		m.metrics.err = msg.err
+
 	case healthMsg:
 		h := HealthResponse(msg)
 		m.health = &h
@@ -338,11 +550,96 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.err = msg.err
 		m.loading = false
 
-	case terminateMsg:
-		if msg.success {
-			// Refresh sessions
-			return m, m.fetchSessions()
+	case terminateResultMsg:
+		if msg.err != nil {
+			m.err = msg.err
+			break
+		}
+		for _, id := range msg.userIDs {
+			delete(m.selectedUsers, id)
+		}
+		return m, m.fetchSessions()
+
+	case auditConnectedMsg:
+		m.auditStatus = "live"
+		cmds = append(cmds, m.listenAuditStream())
+
+	case auditDisconnectedMsg:
+		m.auditStatus = "disconnected"
+		if !msg.permanent {
+			cmds = append(cmds, m.listenAuditStream())
+		}
+
+	case auditEventMsg:
+		m.auditLog = append([]auditEventMsg{msg}, m.auditLog...)
+		if len(m.auditLog) > maxAuditLog {
+			m.auditLog = m.auditLog[:maxAuditLog]
 		}
+		cmds = append(cmds, m.listenAuditStream())
+
+	case replaysMsg:
+		m.replays = msg
+		m.updateReplaysTable()
+		m.loading = false
+
+	case replayTimelineMsg:
+		m.playback = newReplayPlayback(msg.entry, msg.events)
+		m.view = "replay_playback"
+		return m, m.playback.tickCmd()
+
+	case playbackTickMsg:
+		if m.playback != nil && m.view == "replay_playback" {
+			m.playback.advance()
+			return m, m.playback.tickCmd()
+		}
+
+	case replayExportedMsg:
+		if m.playback != nil {
+			m.playback.exportPath = msg.path
+			m.playback.exportErr = msg.err
+		}
+
+	case usersMsg:
+		m.users = msg
+		m.updateUsersTable()
+		m.loading = false
+
+	case userDetailMsg:
+		if msg.err != nil {
+			m.err = msg.err
+			break
+		}
+		detail := msg.detail
+		m.userEdit = newUserEditForm(&detail)
+		m.view = "user_edit"
+		return m, textinput.Blink
+
+	case userActionMsg:
+		if msg.err != nil {
+			m.err = msg.err
+			break
+		}
+		return m, m.fetchUsers()
+
+	case userExportedMsg:
+		if msg.err != nil {
+			m.err = msg.err
+		}
+
+	case userImportPreviewMsg:
+		if msg.err != nil {
+			m.err = msg.err
+			break
+		}
+		m.userImportPreview = &userImportPreview{users: msg.users, diffs: msg.diffs}
+		m.view = "user_import_preview"
+
+	case userImportAppliedMsg:
+		if msg.err != nil {
+			m.err = msg.err
+			break
+		}
+		return m, m.fetchUsers()
 
 	case spinner.TickMsg:
 		var cmd tea.Cmd
@@ -350,15 +647,32 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		cmds = append(cmds, cmd)
 	}
 
-	// Update table
+	// Update the table backing the active view
 	var cmd tea.Cmd
-	m.table, cmd = m.table.Update(msg)
+	switch m.view {
+	case "replays":
+		m.replaysTable, cmd = m.replaysTable.Update(msg)
+	case "users":
+		m.usersTable, cmd = m.usersTable.Update(msg)
+	default:
+		m.table, cmd = m.table.Update(msg)
+	}
 	cmds = append(cmds, cmd)
 
 	return m, tea.Batch(cmds...)
 }
 
 func (m *model) updateTable() {
+	live := make(map[string]bool, len(m.sessions))
+	for _, s := range m.sessions {
+		live[s.UserID] = true
+	}
+	for id := range m.selectedUsers {
+		if !live[id] {
+			delete(m.selectedUsers, id)
+		}
+	}
+
 	rows := make([]table.Row, len(m.sessions))
 	for i, s := range m.sessions {
 		statusStyle := lipgloss.NewStyle()
@@ -371,8 +685,13 @@ func (m *model) updateTable() {
 			statusStyle = errorStyle
 		}
 
+		userID := s.UserID
+		if m.selectedUsers[userID] {
+			userID = "✓ " + userID
+		}
+
 		rows[i] = table.Row{
-			s.UserID,
+			userID,
 			statusStyle.Render(s.Status),
 			fmt.Sprintf("%.1f", s.CPUPercent),
 			fmt.Sprintf("%.0f", s.MemoryMB),
@@ -392,8 +711,30 @@ func (m model) View() string {
 		}
 	case "replays":
 		return m.replaysView()
+	case "replay_playback":
+		if m.playback != nil {
+			return m.playbackView()
+		}
 	case "users":
 		return m.usersView()
+	case "user_edit":
+		if m.userEdit != nil {
+			return m.userEditView()
+		}
+	case "user_delete_confirm":
+		if m.userDeleteConfirm != nil {
+			return m.userDeleteConfirmView()
+		}
+	case "user_import_preview":
+		if m.userImportPreview != nil {
+			return m.userImportPreviewView()
+		}
+	case "metrics":
+		return m.metricsView()
+	case "terminate_confirm":
+		if m.terminateConfirm != nil {
+			return m.terminateConfirmView()
+		}
 	}
 	return m.mainView()
 }
@@ -417,12 +758,13 @@ func (m model) mainView() string {
 		}
 
 		status := lipgloss.NewStyle().Foreground(lipgloss.Color(statusColor)).Render(statusIcon)
-		b.WriteString(fmt.Sprintf("Status: %s %s  |  Version: %s  |  Uptime: %s  |  Sessions: %d\n",
+		b.WriteString(fmt.Sprintf("Status: %s %s  |  Version: %s  |  Uptime: %s  |  Sessions: %d  |  Feed: %s\n",
 			status,
 			m.health.Status,
 			m.health.Version,
 			formatDuration(m.health.UptimeSeconds),
 			m.health.ActiveSessions,
+			streamStatusLabel(m.streamStatus),
 		))
 	}
 
@@ -439,13 +781,20 @@ func (m model) mainView() string {
 
 	b.WriteString("\n")
 
-	// Loading indicator or table
+	// Loading indicator or table, with the live audit tail alongside it
+	var left strings.Builder
 	if m.loading && len(m.sessions) == 0 {
-		b.WriteString(m.spinner.View() + " Loading sessions...")
+		left.WriteString(m.spinner.View() + " Loading sessions...")
 	} else {
-		b.WriteString(m.table.View())
+		left.WriteString(m.table.View())
+	}
+	if len(m.selectedUsers) > 0 {
+		left.WriteString(fmt.Sprintf("\n%d selected", len(m.selectedUsers)))
 	}
 
+	audit := renderAuditPane(m.auditLog, m.auditStatus, 40)
+	b.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, left.String(), "  ", audit))
+
 	b.WriteString("\n\n")
 
 	// Last update time
@@ -511,39 +860,9 @@ func (m model) detailsView() string {
 	return b.String()
 }
 
-func (m model) replaysView() string {
-	var b strings.Builder
+// replaysView and playbackView live in replays.go.
 
-	title := titleStyle.Render(" Execution Replays ")
-	b.WriteString(title)
-	b.WriteString("\n\n")
-
-	b.WriteString("Recent executions stored for replay:\n\n")
-	b.WriteString(helpStyle.Render("Note: Replay data loaded from /api/v1/replays"))
-	b.WriteString("\n\n")
-	b.WriteString("(Replay list fetching not yet implemented in TUI)")
-	b.WriteString("\n\n")
-	b.WriteString(helpStyle.Render("Press ESC to go back, 'u' for users, 'p' for replays"))
-
-	return b.String()
-}
-
-func (m model) usersView() string {
-	var b strings.Builder
-
-	title := titleStyle.Render(" User Management ")
-	b.WriteString(title)
-	b.WriteString("\n\n")
-
-	b.WriteString("Configured users:\n\n")
-	b.WriteString(helpStyle.Render("Note: User data loaded from /api/v1/admin/users"))
-	b.WriteString("\n\n")
-	b.WriteString("(User list fetching not yet implemented in TUI)")
-	b.WriteString("\n\n")
-	b.WriteString(helpStyle.Render("Press ESC to go back, 'u' for users, 'p' for replays"))
-
-	return b.String()
-}
+// usersView and the edit/delete/import sub-views live in users.go.
 
 // API calls
 func (m model) fetchHealth() tea.Cmd {
@@ -600,18 +919,6 @@ func (m model) fetchStats() tea.Cmd {
 	}
 }
 
-func (m model) terminateSession(userID string) tea.Cmd {
-	return func() tea.Msg {
-		resp, err := m.doRequest("DELETE", "/api/v1/admin/sessions/"+userID, nil)
-		if err != nil {
-			return errorMsg{err}
-		}
-		resp.Body.Close()
-
-		return terminateMsg{success: resp.StatusCode == 204, userID: userID}
-	}
-}
-
 func (m model) doRequest(method, path string, body io.Reader) (*http.Response, error) {
 	req, err := http.NewRequest(method, m.apiURL+path, body)
 	if err != nil {
@@ -648,9 +955,21 @@ func stringOrNA(s *string) string {
 	return *s
 }
 
+func streamStatusLabel(status string) string {
+	switch status {
+	case "live":
+		return successStyle.Render("live")
+	case "polling":
+		return warningStyle.Render("polling")
+	default:
+		return helpStyle.Render("connecting")
+	}
+}
+
 func main() {
 	apiURL := flag.String("api", "http://localhost:8080", "CAGE API URL")
 	token := flag.String("token", "", "Admin API token")
+	promURL := flag.String("prom", "", "Prometheus URL for the metrics view")
 	flag.Parse()
 
 	// Check for env vars
@@ -660,9 +979,12 @@ func main() {
 	if envAPI := os.Getenv("CAGE_API_URL"); envAPI != "" {
 		*apiURL = envAPI
 	}
+	if *promURL == "" {
+		*promURL = os.Getenv("CAGE_PROM_URL")
+	}
 
 	p := tea.NewProgram(
-		initialModel(*apiURL, *token),
+		initialModel(*apiURL, *token, *promURL),
 		tea.WithAltScreen(),
 	)
 
@@ -0,0 +1,243 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/prometheus/client_golang/api"
+	promv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	prommodel "github.com/prometheus/common/model"
+)
+
+// metricsPreset is one of the preset queries bound to the 1..9 keys in
+// the metrics view.
+type metricsPreset struct {
+	Key   string
+	Label string
+	Query string
+}
+
+var metricsPresets = []metricsPreset{
+	{"1", "execution rate", "rate(cage_executions_total[5m])"},
+	{"2", "p95 exec duration", "histogram_quantile(0.95, cage_execution_duration_seconds_bucket)"},
+	{"3", "memory by user", "sum by (user)(cage_container_memory_bytes)"},
+	{"4", "CPU by user", "sum by (user)(rate(cage_container_cpu_seconds_total[5m]))"},
+	{"5", "error rate", "rate(cage_executions_total{status=\"error\"}[5m])"},
+	{"6", "active sessions", "cage_active_sessions"},
+	{"7", "security events", "rate(cage_security_events_total[5m])"},
+	{"8", "queue depth", "cage_execution_queue_depth"},
+	{"9", "disk usage by user", "sum by (user)(cage_container_disk_bytes)"},
+}
+
+var metricsSteps = []time.Duration{15 * time.Second, 1 * time.Minute, 5 * time.Minute}
+var metricsWindows = []time.Duration{15 * time.Minute, time.Hour, 6 * time.Hour, 24 * time.Hour}
+
+// metricsState holds the embedded PromQL panel's client and UI state.
+type metricsState struct {
+	promURL string
+	client  promv1.API
+
+	input     textinput.Model
+	stepIdx   int
+	windowIdx int
+
+	result  prommodel.Value
+	err     error
+	loading bool
+}
+
+func newMetricsState(promURL string) metricsState {
+	ti := textinput.New()
+	ti.Placeholder = "PromQL query, e.g. rate(cage_executions_total[5m])"
+	ti.CharLimit = 256
+
+	var client promv1.API
+	if promURL != "" {
+		if c, err := api.NewClient(api.Config{Address: promURL}); err == nil {
+			client = promv1.NewAPI(c)
+		}
+	}
+
+	return metricsState{
+		promURL:   promURL,
+		client:    client,
+		input:     ti,
+		stepIdx:   1,
+		windowIdx: 0,
+	}
+}
+
+type promResultMsg struct {
+	result prommodel.Value
+	err    error
+}
+
+func (m model) runPromQuery(query string) tea.Cmd {
+	client := m.metrics.client
+	step := metricsSteps[m.metrics.stepIdx]
+	window := metricsWindows[m.metrics.windowIdx]
+
+	return func() tea.Msg {
+		if client == nil {
+			return promResultMsg{err: fmt.Errorf("no Prometheus URL configured (set --prom or CAGE_PROM_URL)")}
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		r := promv1.Range{
+			Start: time.Now().Add(-window),
+			End:   time.Now(),
+			Step:  step,
+		}
+
+		result, _, err := client.QueryRange(ctx, query, r)
+		if err != nil {
+			return promResultMsg{err: fmt.Errorf("query failed: %w", err)}
+		}
+		return promResultMsg{result: result}
+	}
+}
+
+// handleMetricsKey processes key presses in the metrics view. Typing
+// goes to the query input; everything else is a view-local binding.
+// Returns handled=false only for keys that should fall through to the
+// model's global bindings (back, quit, ...).
+//
+// The "r"/"t" step/window cycling and "1"-"9" presets only fire with a
+// ctrl modifier, since the input is focused for free-form typing
+// whenever this view is active and those are all legal PromQL
+// characters (e.g. the leading "r" of "rate(...)" or digits in
+// "histogram_quantile(0.95, ...)").
+func (m model) handleMetricsKey(msg tea.KeyMsg) (model, tea.Cmd, bool) {
+	switch msg.String() {
+	case "esc", "q", "ctrl+c":
+		return m, nil, false
+	case "enter":
+		query := m.metrics.input.Value()
+		if query == "" {
+			return m, nil, true
+		}
+		m.metrics.loading = true
+		return m, m.runPromQuery(query), true
+	case "ctrl+r":
+		m.metrics.stepIdx = (m.metrics.stepIdx + 1) % len(metricsSteps)
+		return m, nil, true
+	case "ctrl+t":
+		m.metrics.windowIdx = (m.metrics.windowIdx + 1) % len(metricsWindows)
+		return m, nil, true
+	}
+
+	for _, preset := range metricsPresets {
+		if msg.String() == "ctrl+"+preset.Key {
+			m.metrics.input.SetValue(preset.Query)
+			m.metrics.loading = true
+			return m, m.runPromQuery(preset.Query), true
+		}
+	}
+
+	var cmd tea.Cmd
+	m.metrics.input, cmd = m.metrics.input.Update(msg)
+	return m, cmd, true
+}
+
+func (m model) metricsView() string {
+	var b strings.Builder
+
+	title := titleStyle.Render(" Metrics (PromQL) ")
+	b.WriteString(title)
+	b.WriteString("\n\n")
+
+	if m.metrics.promURL == "" {
+		b.WriteString(warningStyle.Render("No Prometheus URL configured; set --prom or CAGE_PROM_URL"))
+		b.WriteString("\n\n")
+	}
+
+	b.WriteString("Query: ")
+	b.WriteString(m.metrics.input.View())
+	b.WriteString("\n\n")
+
+	b.WriteString(fmt.Sprintf("step: %s  |  window: %s\n\n", metricsSteps[m.metrics.stepIdx], metricsWindows[m.metrics.windowIdx]))
+
+	var presetLine strings.Builder
+	for _, preset := range metricsPresets {
+		presetLine.WriteString(fmt.Sprintf("ctrl+%s:%s  ", preset.Key, preset.Label))
+	}
+	b.WriteString(helpStyle.Render(presetLine.String()))
+	b.WriteString("\n\n")
+
+	switch {
+	case m.metrics.loading:
+		b.WriteString(m.spinner.View() + " Running query...")
+	case m.metrics.err != nil:
+		b.WriteString(errorStyle.Render(fmt.Sprintf("Error: %v", m.metrics.err)))
+	case m.metrics.result != nil:
+		b.WriteString(renderPromResult(m.metrics.result))
+	default:
+		b.WriteString(helpStyle.Render("Enter a query or press ctrl+1-9 for a preset, then Enter to run it."))
+	}
+
+	b.WriteString("\n\n")
+	b.WriteString(helpStyle.Render("ctrl+r: cycle step  |  ctrl+t: cycle window  |  ctrl+1-9: presets  |  Enter: run  |  ESC: back"))
+
+	return b.String()
+}
+
+func renderPromResult(v prommodel.Value) string {
+	switch val := v.(type) {
+	case prommodel.Vector:
+		var b strings.Builder
+		for _, sample := range val {
+			b.WriteString(fmt.Sprintf("%s = %s\n", sample.Metric.String(), sample.Value.String()))
+		}
+		return b.String()
+	case prommodel.Matrix:
+		var b strings.Builder
+		for _, series := range val {
+			b.WriteString(series.Metric.String())
+			b.WriteString("\n")
+			b.WriteString(sparkline(series.Values))
+			b.WriteString("\n")
+		}
+		return b.String()
+	default:
+		return v.String()
+	}
+}
+
+var sparkBars = []rune("▁▂▃▄▅▆▇█")
+
+func sparkline(points []prommodel.SamplePair) string {
+	if len(points) == 0 {
+		return ""
+	}
+
+	min, max := float64(points[0].Value), float64(points[0].Value)
+	for _, p := range points {
+		f := float64(p.Value)
+		if f < min {
+			min = f
+		}
+		if f > max {
+			max = f
+		}
+	}
+
+	var b strings.Builder
+	span := max - min
+	for _, p := range points {
+		f := float64(p.Value)
+		idx := 0
+		if span > 0 {
+			idx = int((f - min) / span * float64(len(sparkBars)-1))
+		}
+		b.WriteRune(sparkBars[idx])
+	}
+
+	return lipgloss.NewStyle().Foreground(lipgloss.Color("#7D56F4")).Render(b.String())
+}
@@ -0,0 +1,497 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ReplayTimelineEvent is one recorded stdin/stdout/stderr/exit event in a
+// stored execution's replay timeline.
+type ReplayTimelineEvent struct {
+	ElapsedMS int64  `json:"elapsed_ms"`
+	Stream    string `json:"stream"` // "stdout", "stderr", "stdin", "exit"
+	Data      string `json:"data"`
+}
+
+// Messages
+type replaysMsg []ReplayEntry
+type replayTimelineMsg struct {
+	entry  ReplayEntry
+	events []ReplayTimelineEvent
+}
+type playbackTickMsg time.Time
+type replayExportedMsg struct {
+	path string
+	err  error
+}
+
+const playbackTickInterval = 100 * time.Millisecond
+
+// replayFilter holds the optional user/language/status filters applied to
+// the replay list, plus whether its fields are currently focused for
+// editing.
+type replayFilter struct {
+	user     textinput.Model
+	language textinput.Model
+	status   textinput.Model
+	editing  bool
+	focus    int // 0=user, 1=language, 2=status
+}
+
+const replayFilterFieldCount = 3
+
+func newReplayFilter() replayFilter {
+	f := replayFilter{}
+	f.user = textinput.New()
+	f.user.Placeholder = "user"
+	f.language = textinput.New()
+	f.language.Placeholder = "language"
+	f.status = textinput.New()
+	f.status.Placeholder = "status"
+	return f
+}
+
+func (f *replayFilter) setFocus(idx int) {
+	f.user.Blur()
+	f.language.Blur()
+	f.status.Blur()
+	f.focus = (idx + replayFilterFieldCount) % replayFilterFieldCount
+
+	switch f.focus {
+	case 0:
+		f.user.Focus()
+	case 1:
+		f.language.Focus()
+	case 2:
+		f.status.Focus()
+	}
+}
+
+func (f *replayFilter) blur() {
+	f.user.Blur()
+	f.language.Blur()
+	f.status.Blur()
+}
+
+// active reports whether any filter field is non-empty.
+func (f *replayFilter) active() bool {
+	return f.user.Value() != "" || f.language.Value() != "" || f.status.Value() != ""
+}
+
+// matches reports whether r satisfies every non-empty filter field.
+func (f *replayFilter) matches(r ReplayEntry) bool {
+	if v := strings.TrimSpace(f.user.Value()); v != "" && !strings.Contains(strings.ToLower(r.UserID), strings.ToLower(v)) {
+		return false
+	}
+	if v := strings.TrimSpace(f.language.Value()); v != "" && !strings.EqualFold(r.Language, v) {
+		return false
+	}
+	if v := strings.TrimSpace(f.status.Value()); v != "" && !strings.EqualFold(r.Status, v) {
+		return false
+	}
+	return true
+}
+
+// replayPlayback holds the state of an in-progress replay scrub/playback.
+type replayPlayback struct {
+	entry     ReplayEntry
+	events    []ReplayTimelineEvent
+	position  int
+	elapsedMS int64
+	paused    bool
+	speed     float64
+
+	exportPath string
+	exportErr  error
+}
+
+func newReplayPlayback(entry ReplayEntry, events []ReplayTimelineEvent) *replayPlayback {
+	return &replayPlayback{entry: entry, events: events, speed: 1}
+}
+
+func (p *replayPlayback) totalMS() int64 {
+	if len(p.events) == 0 {
+		return 0
+	}
+	return p.events[len(p.events)-1].ElapsedMS
+}
+
+func (p *replayPlayback) tickCmd() tea.Cmd {
+	return tea.Tick(playbackTickInterval, func(t time.Time) tea.Msg {
+		return playbackTickMsg(t)
+	})
+}
+
+// advance moves the playhead forward by one tick's worth of elapsed time
+// (scaled by speed), pausing automatically at the end of the timeline.
+func (p *replayPlayback) advance() {
+	if p.paused {
+		return
+	}
+
+	p.elapsedMS += int64(float64(playbackTickInterval.Milliseconds()) * p.speed)
+	total := p.totalMS()
+	if p.elapsedMS >= total {
+		p.elapsedMS = total
+		p.paused = true
+	}
+
+	for p.position < len(p.events) && p.events[p.position].ElapsedMS <= p.elapsedMS {
+		p.position++
+	}
+}
+
+func (p *replayPlayback) stepForward() {
+	if p.position >= len(p.events) {
+		return
+	}
+	p.elapsedMS = p.events[p.position].ElapsedMS
+	p.position++
+}
+
+func (p *replayPlayback) stepBackward() {
+	if p.position == 0 {
+		return
+	}
+	p.position--
+	if p.position == 0 {
+		p.elapsedMS = 0
+	} else {
+		p.elapsedMS = p.events[p.position-1].ElapsedMS
+	}
+}
+
+func (p *replayPlayback) seekStart() {
+	p.position = 0
+	p.elapsedMS = 0
+}
+
+func (p *replayPlayback) seekEnd() {
+	p.position = len(p.events)
+	p.elapsedMS = p.totalMS()
+}
+
+func (p *replayPlayback) faster() {
+	p.speed *= 2
+	if p.speed > 8 {
+		p.speed = 8
+	}
+}
+
+func (p *replayPlayback) slower() {
+	p.speed /= 2
+	if p.speed < 0.125 {
+		p.speed = 0.125
+	}
+}
+
+// handleReplaysKey processes key presses specific to the replays list and
+// playback views. It returns handled=false for keys that should fall
+// through to the model's global key bindings (back, quit, nav, ...).
+func (m model) handleReplaysKey(msg tea.KeyMsg) (model, tea.Cmd, bool) {
+	switch m.view {
+	case "replays":
+		if m.replayFilter.editing {
+			switch msg.String() {
+			case "esc", "enter":
+				m.replayFilter.editing = false
+				m.replayFilter.blur()
+				m.updateReplaysTable()
+				return m, nil, true
+			case "tab", "down":
+				m.replayFilter.setFocus(m.replayFilter.focus + 1)
+				return m, nil, true
+			case "shift+tab", "up":
+				m.replayFilter.setFocus(m.replayFilter.focus - 1)
+				return m, nil, true
+			}
+
+			var cmd tea.Cmd
+			switch m.replayFilter.focus {
+			case 0:
+				m.replayFilter.user, cmd = m.replayFilter.user.Update(msg)
+			case 1:
+				m.replayFilter.language, cmd = m.replayFilter.language.Update(msg)
+			case 2:
+				m.replayFilter.status, cmd = m.replayFilter.status.Update(msg)
+			}
+			m.updateReplaysTable()
+			return m, cmd, true
+		}
+
+		switch msg.String() {
+		case "f":
+			m.replayFilter.editing = true
+			m.replayFilter.setFocus(0)
+			return m, textinput.Blink, true
+		case "F":
+			m.replayFilter = newReplayFilter()
+			m.updateReplaysTable()
+			return m, nil, true
+		case "enter":
+			filtered := m.filteredReplays()
+			if len(filtered) == 0 {
+				return m, nil, true
+			}
+			idx := m.replaysTable.Cursor()
+			if idx >= len(filtered) {
+				return m, nil, true
+			}
+			return m, m.fetchReplayTimeline(filtered[idx]), true
+		}
+
+	case "replay_playback":
+		if m.playback == nil {
+			return m, nil, false
+		}
+		switch msg.String() {
+		case " ":
+			m.playback.paused = !m.playback.paused
+			return m, nil, true
+		case "[":
+			m.playback.stepBackward()
+			return m, nil, true
+		case "]":
+			m.playback.stepForward()
+			return m, nil, true
+		case "+":
+			m.playback.faster()
+			return m, nil, true
+		case "-":
+			m.playback.slower()
+			return m, nil, true
+		case "g":
+			m.playback.seekStart()
+			return m, nil, true
+		case "G":
+			m.playback.seekEnd()
+			return m, nil, true
+		case "e":
+			return m, exportAsciicast(*m.playback), true
+		}
+	}
+
+	return m, nil, false
+}
+
+// filteredReplays returns m.replays narrowed to entries matching the
+// current replayFilter, or m.replays itself when no filter is set.
+func (m model) filteredReplays() []ReplayEntry {
+	if !m.replayFilter.active() {
+		return m.replays
+	}
+
+	filtered := make([]ReplayEntry, 0, len(m.replays))
+	for _, r := range m.replays {
+		if m.replayFilter.matches(r) {
+			filtered = append(filtered, r)
+		}
+	}
+	return filtered
+}
+
+func (m *model) updateReplaysTable() {
+	replays := m.filteredReplays()
+	rows := make([]table.Row, len(replays))
+	for i, r := range replays {
+		rows[i] = table.Row{
+			r.ExecutionID,
+			r.UserID,
+			r.Language,
+			r.Status,
+			r.Timestamp.Format("2006-01-02 15:04:05"),
+		}
+	}
+	m.replaysTable.SetRows(rows)
+}
+
+func (m model) fetchReplays() tea.Cmd {
+	return func() tea.Msg {
+		resp, err := m.doRequest("GET", "/api/v1/replays", nil)
+		if err != nil {
+			return errorMsg{err}
+		}
+		defer resp.Body.Close()
+
+		var result struct {
+			Replays []ReplayEntry `json:"replays"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+			return errorMsg{err}
+		}
+
+		return replaysMsg(result.Replays)
+	}
+}
+
+func (m model) fetchReplayTimeline(entry ReplayEntry) tea.Cmd {
+	return func() tea.Msg {
+		resp, err := m.doRequest("GET", "/api/v1/replays/"+entry.ExecutionID, nil)
+		if err != nil {
+			return errorMsg{err}
+		}
+		defer resp.Body.Close()
+
+		var result struct {
+			Events []ReplayTimelineEvent `json:"events"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+			return errorMsg{err}
+		}
+
+		return replayTimelineMsg{entry: entry, events: result.Events}
+	}
+}
+
+func (m model) replaysView() string {
+	var b strings.Builder
+
+	title := titleStyle.Render(" Execution Replays ")
+	b.WriteString(title)
+	b.WriteString("\n\n")
+
+	if m.replayFilter.editing {
+		b.WriteString(fmt.Sprintf("Filter  user: %s  language: %s  status: %s\n\n",
+			m.replayFilter.user.View(), m.replayFilter.language.View(), m.replayFilter.status.View()))
+	} else if m.replayFilter.active() {
+		b.WriteString(helpStyle.Render(fmt.Sprintf("Filter: user=%q language=%q status=%q (F to clear)",
+			m.replayFilter.user.Value(), m.replayFilter.language.Value(), m.replayFilter.status.Value())))
+		b.WriteString("\n\n")
+	}
+
+	if m.loading && len(m.replays) == 0 {
+		b.WriteString(m.spinner.View() + " Loading replays...")
+	} else {
+		b.WriteString(m.replaysTable.View())
+	}
+
+	b.WriteString("\n\n")
+	if m.replayFilter.editing {
+		b.WriteString(helpStyle.Render("tab: next field  |  enter/esc: apply"))
+	} else {
+		b.WriteString(helpStyle.Render("Enter: play  |  f: filter  |  F: clear filter  |  ESC: back  |  u: users  |  m: metrics  |  r: refresh"))
+	}
+
+	return b.String()
+}
+
+func (m model) playbackView() string {
+	p := m.playback
+	var b strings.Builder
+
+	title := titleStyle.Render(fmt.Sprintf(" Replay: %s ", p.entry.ExecutionID))
+	b.WriteString(title)
+	b.WriteString("\n\n")
+
+	state := "playing"
+	if p.paused {
+		state = "paused"
+	}
+	b.WriteString(fmt.Sprintf("%s  |  speed %.2fx  |  %s\n\n", state, p.speed, scrubberBar(p.elapsedMS, p.totalMS(), 40)))
+
+	for _, evt := range p.events[:p.position] {
+		line := evt.Data
+		style := lipgloss.NewStyle()
+		switch evt.Stream {
+		case "stderr":
+			style = errorStyle
+		case "exit":
+			style = warningStyle
+		}
+		b.WriteString(style.Render(line))
+	}
+
+	b.WriteString("\n\n")
+	b.WriteString(helpStyle.Render(fmt.Sprintf("elapsed %dms / %dms", p.elapsedMS, p.totalMS())))
+	b.WriteString("\n")
+
+	if p.exportPath != "" {
+		b.WriteString(successStyle.Render(fmt.Sprintf("Exported to %s", p.exportPath)))
+		b.WriteString("\n")
+	}
+	if p.exportErr != nil {
+		b.WriteString(errorStyle.Render(fmt.Sprintf("Export failed: %v", p.exportErr)))
+		b.WriteString("\n")
+	}
+
+	b.WriteString(helpStyle.Render("space: pause  [/]: step  +/-: speed  g/G: start/end  e: export  ESC: back"))
+
+	return b.String()
+}
+
+func scrubberBar(elapsedMS, totalMS int64, width int) string {
+	if totalMS <= 0 {
+		return strings.Repeat("░", width)
+	}
+	filled := int(float64(width) * float64(elapsedMS) / float64(totalMS))
+	if filled > width {
+		filled = width
+	}
+	return strings.Repeat("█", filled) + strings.Repeat("░", width-filled)
+}
+
+// asciicastHeader is the header line of an asciicast v2 file.
+type asciicastHeader struct {
+	Version   int               `json:"version"`
+	Width     int               `json:"width"`
+	Height    int               `json:"height"`
+	Timestamp int64             `json:"timestamp"`
+	Env       map[string]string `json:"env"`
+}
+
+// exportAsciicast writes p's timeline to an asciicast v2 JSON file named
+// after the execution ID, so operators can share a session outside the
+// TUI (e.g. with `asciinema play`).
+func exportAsciicast(p replayPlayback) tea.Cmd {
+	return func() tea.Msg {
+		path := fmt.Sprintf("replay-%s.cast", p.entry.ExecutionID)
+
+		f, err := os.Create(path)
+		if err != nil {
+			return replayExportedMsg{err: fmt.Errorf("failed to create file: %w", err)}
+		}
+		defer f.Close()
+
+		header := asciicastHeader{
+			Version:   2,
+			Width:     80,
+			Height:    24,
+			Timestamp: p.entry.Timestamp.Unix(),
+			Env:       map[string]string{"SHELL": "/bin/sh", "TERM": "xterm-256color"},
+		}
+		headerBytes, err := json.Marshal(header)
+		if err != nil {
+			return replayExportedMsg{err: fmt.Errorf("failed to marshal header: %w", err)}
+		}
+		if _, err := fmt.Fprintln(f, string(headerBytes)); err != nil {
+			return replayExportedMsg{err: fmt.Errorf("failed to write header: %w", err)}
+		}
+
+		for _, evt := range p.events {
+			if evt.Stream != "stdout" && evt.Stream != "stderr" {
+				continue
+			}
+			line, err := json.Marshal([]interface{}{
+				float64(evt.ElapsedMS) / 1000.0,
+				"o",
+				evt.Data,
+			})
+			if err != nil {
+				return replayExportedMsg{err: fmt.Errorf("failed to marshal event: %w", err)}
+			}
+			if _, err := fmt.Fprintln(f, string(line)); err != nil {
+				return replayExportedMsg{err: fmt.Errorf("failed to write event: %w", err)}
+			}
+		}
+
+		return replayExportedMsg{path: path}
+	}
+}
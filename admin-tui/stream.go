@@ -0,0 +1,189 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	sseMinBackoff = 1 * time.Second
+	sseMaxBackoff = 30 * time.Second
+)
+
+// errStreamNotFound signals that the server has no /api/v1/admin/stream
+// endpoint; the caller should stop reconnecting and rely on polling.
+var errStreamNotFound = fmt.Errorf("admin stream endpoint not found")
+
+// Messages produced by the SSE reader goroutine.
+type sseConnectedMsg struct{}
+type sseDisconnectedMsg struct {
+	err       error
+	permanent bool
+}
+type sessionEventMsg struct {
+	Type    string         `json:"type"`
+	Session SessionSummary `json:"session"`
+}
+type statsDeltaMsg SystemStats
+type securityEventMsg struct {
+	Message string    `json:"message"`
+	At      time.Time `json:"at"`
+}
+
+// listenStream turns the model's streamSub channel into a bubbletea
+// command; re-issue it after handling every message to keep listening.
+func (m model) listenStream() tea.Cmd {
+	sub := m.streamSub
+	return func() tea.Msg {
+		return <-sub
+	}
+}
+
+// startStream launches the SSE reader goroutine for
+// GET /api/v1/admin/stream in the background and returns the command
+// that reads its first message. The goroutine reconnects with
+// exponential backoff on transient failures; a 404 is treated as
+// permanent, so the caller should rely on the polling fallback instead.
+func (m model) startStream() tea.Cmd {
+	sub := m.streamSub
+	apiURL := m.apiURL
+	token := m.token
+
+	go func() {
+		backoff := sseMinBackoff
+		for {
+			err := streamOnce(apiURL, token, sub)
+			if err == errStreamNotFound {
+				sub <- sseDisconnectedMsg{err: err, permanent: true}
+				return
+			}
+
+			sub <- sseDisconnectedMsg{err: err}
+			time.Sleep(backoff)
+			backoff *= 2
+			if backoff > sseMaxBackoff {
+				backoff = sseMaxBackoff
+			}
+		}
+	}()
+
+	return m.listenStream()
+}
+
+// streamOnce opens a single SSE connection and blocks, dispatching
+// parsed events onto sub, until the connection drops or errors.
+func streamOnce(apiURL, token string, sub chan tea.Msg) error {
+	req, err := http.NewRequestWithContext(context.Background(), "GET", apiURL+"/api/v1/admin/stream", nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	if token != "" {
+		req.Header.Set("Authorization", "ApiKey "+token)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return errStreamNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("admin stream failed (status %d)", resp.StatusCode)
+	}
+
+	sub <- sseConnectedMsg{}
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var eventType string
+	var dataLines []string
+
+	flush := func() {
+		if eventType == "" || len(dataLines) == 0 {
+			return
+		}
+		dispatchSSEEvent(eventType, strings.Join(dataLines, "\n"), sub)
+		eventType = ""
+		dataLines = nil
+	}
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			flush()
+		case strings.HasPrefix(line, "event:"):
+			eventType = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			dataLines = append(dataLines, strings.TrimSpace(strings.TrimPrefix(line, "data:")))
+		}
+	}
+	flush()
+
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	return fmt.Errorf("stream closed by server")
+}
+
+func dispatchSSEEvent(eventType, data string, sub chan tea.Msg) {
+	switch eventType {
+	case "session.created", "session.updated", "session.terminated":
+		var evt sessionEventMsg
+		if err := json.Unmarshal([]byte(data), &evt); err != nil {
+			return
+		}
+		evt.Type = eventType
+		sub <- evt
+	case "stats.tick":
+		var s statsDeltaMsg
+		if err := json.Unmarshal([]byte(data), &s); err != nil {
+			return
+		}
+		sub <- s
+	case "security.event":
+		var evt securityEventMsg
+		if err := json.Unmarshal([]byte(data), &evt); err != nil {
+			return
+		}
+		sub <- evt
+	}
+}
+
+// applySessionEvent patches m.sessions in place from a streamed
+// session.created/updated/terminated event instead of replacing the
+// whole slice, then refreshes the table.
+func (m *model) applySessionEvent(evt sessionEventMsg) {
+	if evt.Type == "session.terminated" {
+		for i, s := range m.sessions {
+			if s.UserID == evt.Session.UserID {
+				m.sessions = append(m.sessions[:i], m.sessions[i+1:]...)
+				break
+			}
+		}
+		m.updateTable()
+		return
+	}
+
+	for i, s := range m.sessions {
+		if s.UserID == evt.Session.UserID {
+			m.sessions[i] = evt.Session
+			m.updateTable()
+			return
+		}
+	}
+	m.sessions = append(m.sessions, evt.Session)
+	m.updateTable()
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// terminateConfirm guards one or more session terminations behind an
+// explicit confirmation: a single target must be confirmed by typing its
+// user ID, a bulk target by typing "yes".
+type terminateConfirm struct {
+	userIDs  []string
+	sessions []SessionSummary // the matching SessionSummary for each userID, for display
+	typed    textinput.Model
+}
+
+// newTerminateConfirm builds the confirmation overlay for terminating
+// userIDs, looking up their current container ID and exec count from
+// sessions for display.
+func newTerminateConfirm(sessions []SessionSummary, userIDs []string) *terminateConfirm {
+	byID := make(map[string]SessionSummary, len(sessions))
+	for _, s := range sessions {
+		byID[s.UserID] = s
+	}
+
+	matched := make([]SessionSummary, 0, len(userIDs))
+	for _, id := range userIDs {
+		matched = append(matched, byID[id])
+	}
+
+	ti := textinput.New()
+	if len(userIDs) == 1 {
+		ti.Placeholder = "type user id to confirm"
+	} else {
+		ti.Placeholder = "type yes to confirm"
+	}
+	ti.Focus()
+
+	return &terminateConfirm{userIDs: userIDs, sessions: matched, typed: ti}
+}
+
+// confirmed reports whether the typed text satisfies this confirmation's
+// requirement: the exact user ID for a single target, or "yes" for bulk.
+func (c *terminateConfirm) confirmed() bool {
+	if len(c.userIDs) == 1 {
+		return c.typed.Value() == c.userIDs[0]
+	}
+	return c.typed.Value() == "yes"
+}
+
+// terminateResultMsg reports the outcome of a single or batch
+// termination, so the caller can refresh sessions and clear selection.
+type terminateResultMsg struct {
+	userIDs []string
+	err     error
+}
+
+func (m model) terminateSessionConfirmed(userID string) tea.Cmd {
+	return func() tea.Msg {
+		resp, err := m.doRequest("DELETE", "/api/v1/admin/sessions/"+userID, nil)
+		if err != nil {
+			return terminateResultMsg{userIDs: []string{userID}, err: err}
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode != 204 {
+			return terminateResultMsg{userIDs: []string{userID}, err: fmt.Errorf("terminate failed (status %d)", resp.StatusCode)}
+		}
+		return terminateResultMsg{userIDs: []string{userID}}
+	}
+}
+
+// batchTerminateSessions terminates all of userIDs in a single request to
+// the batch endpoint, so an operator clearing several fat-fingered or
+// compromised sessions doesn't fire N separate DELETE requests.
+func (m model) batchTerminateSessions(userIDs []string) tea.Cmd {
+	return func() tea.Msg {
+		body, err := json.Marshal(struct {
+			UserIDs []string `json:"user_ids"`
+		}{UserIDs: userIDs})
+		if err != nil {
+			return terminateResultMsg{userIDs: userIDs, err: err}
+		}
+
+		resp, err := m.doRequest("POST", "/api/v1/admin/sessions:batchDelete", bytes.NewReader(body))
+		if err != nil {
+			return terminateResultMsg{userIDs: userIDs, err: err}
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != 200 && resp.StatusCode != 204 {
+			return terminateResultMsg{userIDs: userIDs, err: fmt.Errorf("batch terminate failed (status %d)", resp.StatusCode)}
+		}
+		return terminateResultMsg{userIDs: userIDs}
+	}
+}
+
+// handleTerminateKey processes key presses in the terminate confirmation
+// overlay. Returns handled=false for keys that should fall through (none
+// currently, but kept for symmetry with the other per-view handlers).
+func (m model) handleTerminateKey(msg tea.KeyMsg) (model, tea.Cmd, bool) {
+	if m.terminateConfirm == nil {
+		return m, nil, false
+	}
+
+	switch msg.String() {
+	case "esc":
+		m.terminateConfirm = nil
+		m.view = "main"
+		return m, nil, true
+	case "enter":
+		if !m.terminateConfirm.confirmed() {
+			return m, nil, true
+		}
+		userIDs := m.terminateConfirm.userIDs
+		m.terminateConfirm = nil
+		m.view = "main"
+		// Selection is cleared on terminateResultMsg, once the request's
+		// outcome is known, so a failed terminate doesn't silently drop it.
+		if len(userIDs) == 1 {
+			return m, m.terminateSessionConfirmed(userIDs[0]), true
+		}
+		return m, m.batchTerminateSessions(userIDs), true
+	}
+
+	var cmd tea.Cmd
+	m.terminateConfirm.typed, cmd = m.terminateConfirm.typed.Update(msg)
+	return m, cmd, true
+}
+
+func (m model) terminateConfirmView() string {
+	c := m.terminateConfirm
+	var b strings.Builder
+
+	b.WriteString(titleStyle.Render(" Confirm Terminate "))
+	b.WriteString("\n\n")
+
+	for _, s := range c.sessions {
+		b.WriteString(fmt.Sprintf("  %-18s %s\n", "User:", s.UserID))
+		b.WriteString(fmt.Sprintf("  %-18s %s\n", "Container ID:", stringOrNA(s.ContainerID)))
+		b.WriteString(fmt.Sprintf("  %-18s %d\n\n", "Exec Count:", s.ExecutionCount))
+	}
+
+	if len(c.userIDs) == 1 {
+		b.WriteString(warningStyle.Render(fmt.Sprintf("This will terminate %q.", c.userIDs[0])))
+		b.WriteString("\n\n")
+		b.WriteString(fmt.Sprintf("Type the user id to confirm: %s\n\n", c.typed.View()))
+	} else {
+		b.WriteString(warningStyle.Render(fmt.Sprintf("This will terminate %d selected sessions.", len(c.userIDs))))
+		b.WriteString("\n\n")
+		b.WriteString(fmt.Sprintf("Type \"yes\" to confirm: %s\n\n", c.typed.View()))
+	}
+
+	b.WriteString(helpStyle.Render("enter: confirm  esc: cancel"))
+
+	return b.String()
+}
@@ -0,0 +1,619 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"gopkg.in/yaml.v3"
+)
+
+// availableLanguages is the fixed set of languages an operator can grant
+// to a user in the edit form's multi-select.
+var availableLanguages = []string{"python", "javascript", "typescript", "go", "rust", "bash"}
+
+// Messages
+type usersMsg []UserEntry
+type userDetailMsg struct {
+	detail UserDetail
+	err    error
+}
+type userActionMsg struct {
+	action string // "toggled", "deleted", "key_rotated", "saved"
+	userID string
+	err    error
+}
+type userExportedMsg struct {
+	path string
+	err  error
+}
+type userImportPreviewMsg struct {
+	users []importedUser
+	diffs []string
+	err   error
+}
+type userImportAppliedMsg struct {
+	count int
+	err   error
+}
+
+// userEditForm is the multi-field edit/create form for a single user.
+type userEditForm struct {
+	creating  bool
+	enabled   bool
+	userID    textinput.Model
+	cpu       textinput.Model
+	memory    textinput.Model
+	execQuota textinput.Model
+	languages map[string]bool
+	langIdx   int
+	focus     int // 0=userID, 1=cpu, 2=memory, 3=execQuota, 4=languages
+}
+
+const userEditFieldCount = 5
+
+func newUserEditForm(detail *UserDetail) *userEditForm {
+	f := &userEditForm{languages: map[string]bool{}}
+
+	f.userID = textinput.New()
+	f.userID.Placeholder = "user id"
+	f.cpu = textinput.New()
+	f.cpu.Placeholder = "cpu quota (cores)"
+	f.memory = textinput.New()
+	f.memory.Placeholder = "memory quota (MB)"
+	f.execQuota = textinput.New()
+	f.execQuota.Placeholder = "exec quota (per hour)"
+
+	if detail == nil {
+		f.creating = true
+		f.enabled = true
+		f.userID.Focus()
+		return f
+	}
+
+	f.enabled = detail.Enabled
+	f.userID.SetValue(detail.UserID)
+	f.cpu.SetValue(strconv.FormatFloat(detail.CPUQuota, 'f', -1, 64))
+	f.memory.SetValue(strconv.FormatFloat(detail.MemoryQuotaMB, 'f', -1, 64))
+	f.execQuota.SetValue(strconv.Itoa(detail.ExecQuota))
+	for _, lang := range detail.AllowedLanguages {
+		f.languages[lang] = true
+	}
+	f.cpu.Focus()
+	f.focus = 1
+	return f
+}
+
+func (f *userEditForm) setFocus(idx int) {
+	f.userID.Blur()
+	f.cpu.Blur()
+	f.memory.Blur()
+	f.execQuota.Blur()
+	f.focus = (idx + userEditFieldCount) % userEditFieldCount
+
+	switch f.focus {
+	case 0:
+		if f.creating {
+			f.userID.Focus()
+		} else {
+			f.focus = 1
+			f.cpu.Focus()
+		}
+	case 1:
+		f.cpu.Focus()
+	case 2:
+		f.memory.Focus()
+	case 3:
+		f.execQuota.Focus()
+	}
+}
+
+func (f *userEditForm) toDetail() UserDetail {
+	cpu, _ := strconv.ParseFloat(f.cpu.Value(), 64)
+	mem, _ := strconv.ParseFloat(f.memory.Value(), 64)
+	exec, _ := strconv.Atoi(f.execQuota.Value())
+
+	var langs []string
+	for _, lang := range availableLanguages {
+		if f.languages[lang] {
+			langs = append(langs, lang)
+		}
+	}
+
+	return UserDetail{
+		UserID:           f.userID.Value(),
+		Enabled:          f.enabled,
+		AllowedLanguages: langs,
+		CPUQuota:         cpu,
+		MemoryQuotaMB:    mem,
+		ExecQuota:        exec,
+	}
+}
+
+// userDeleteConfirm guards a destructive delete behind typing the user ID.
+type userDeleteConfirm struct {
+	userID string
+	typed  textinput.Model
+}
+
+func newUserDeleteConfirm(userID string) *userDeleteConfirm {
+	ti := textinput.New()
+	ti.Placeholder = "type user id to confirm"
+	ti.Focus()
+	return &userDeleteConfirm{userID: userID, typed: ti}
+}
+
+// importedUser is one user parsed from an imported policy file, tagged
+// with whether applying it should POST (new) or PATCH (existing).
+type importedUser struct {
+	detail   UserDetail
+	creating bool
+}
+
+// userImportPreview holds a parsed YAML policy document awaiting
+// confirmation before it's applied as a batch of API calls.
+type userImportPreview struct {
+	path  string
+	users []importedUser
+	diffs []string
+}
+
+func (m model) fetchUsers() tea.Cmd {
+	return func() tea.Msg {
+		resp, err := m.doRequest("GET", "/api/v1/admin/users", nil)
+		if err != nil {
+			return errorMsg{err}
+		}
+		defer resp.Body.Close()
+
+		var result struct {
+			Users []UserEntry `json:"users"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+			return errorMsg{err}
+		}
+
+		return usersMsg(result.Users)
+	}
+}
+
+func (m model) fetchUserDetail(userID string) tea.Cmd {
+	return func() tea.Msg {
+		resp, err := m.doRequest("GET", "/api/v1/admin/users/"+userID, nil)
+		if err != nil {
+			return userDetailMsg{err: err}
+		}
+		defer resp.Body.Close()
+
+		var detail UserDetail
+		if err := json.NewDecoder(resp.Body).Decode(&detail); err != nil {
+			return userDetailMsg{err: err}
+		}
+
+		return userDetailMsg{detail: detail}
+	}
+}
+
+func (m model) saveUser(detail UserDetail, creating bool) tea.Cmd {
+	return func() tea.Msg {
+		body, err := json.Marshal(detail)
+		if err != nil {
+			return userActionMsg{action: "saved", userID: detail.UserID, err: err}
+		}
+
+		method, path := "PATCH", "/api/v1/admin/users/"+detail.UserID
+		if creating {
+			method, path = "POST", "/api/v1/admin/users"
+		}
+
+		resp, err := m.doRequest(method, path, bytes.NewReader(body))
+		if err != nil {
+			return userActionMsg{action: "saved", userID: detail.UserID, err: err}
+		}
+		resp.Body.Close()
+
+		return userActionMsg{action: "saved", userID: detail.UserID}
+	}
+}
+
+func (m model) toggleUser(userID string, enable bool) tea.Cmd {
+	return func() tea.Msg {
+		body, _ := json.Marshal(struct {
+			Enabled bool `json:"enabled"`
+		}{Enabled: enable})
+
+		resp, err := m.doRequest("PATCH", "/api/v1/admin/users/"+userID, bytes.NewReader(body))
+		if err != nil {
+			return userActionMsg{action: "toggled", userID: userID, err: err}
+		}
+		resp.Body.Close()
+
+		return userActionMsg{action: "toggled", userID: userID}
+	}
+}
+
+func (m model) deleteUser(userID string) tea.Cmd {
+	return func() tea.Msg {
+		resp, err := m.doRequest("DELETE", "/api/v1/admin/users/"+userID, nil)
+		if err != nil {
+			return userActionMsg{action: "deleted", userID: userID, err: err}
+		}
+		resp.Body.Close()
+
+		return userActionMsg{action: "deleted", userID: userID}
+	}
+}
+
+func (m model) rotateUserKey(userID string) tea.Cmd {
+	return func() tea.Msg {
+		resp, err := m.doRequest("POST", "/api/v1/admin/users/"+userID+"/rotate-key", nil)
+		if err != nil {
+			return userActionMsg{action: "key_rotated", userID: userID, err: err}
+		}
+		resp.Body.Close()
+
+		return userActionMsg{action: "key_rotated", userID: userID}
+	}
+}
+
+// userPolicyDoc is the on-disk schema for cage-users-policy.yaml. Both
+// export and import use the full UserDetail record (not the list-view
+// UserEntry summary) so quotas and allowed languages round-trip.
+type userPolicyDoc struct {
+	Users []UserDetail `yaml:"users"`
+}
+
+// exportUsersYAML fetches the full UserDetail record for every user in
+// the list (the table only holds the summary UserEntry) and writes them
+// out as a policy file an operator can check into git and re-import.
+func (m model) exportUsersYAML(users []UserEntry) tea.Cmd {
+	return func() tea.Msg {
+		details := make([]UserDetail, 0, len(users))
+		for _, u := range users {
+			resp, err := m.doRequest("GET", "/api/v1/admin/users/"+u.UserID, nil)
+			if err != nil {
+				return userExportedMsg{err: fmt.Errorf("failed to fetch %s: %w", u.UserID, err)}
+			}
+			var detail UserDetail
+			decodeErr := json.NewDecoder(resp.Body).Decode(&detail)
+			resp.Body.Close()
+			if decodeErr != nil {
+				return userExportedMsg{err: fmt.Errorf("failed to decode %s: %w", u.UserID, decodeErr)}
+			}
+			details = append(details, detail)
+		}
+
+		out, err := yaml.Marshal(userPolicyDoc{Users: details})
+		if err != nil {
+			return userExportedMsg{err: fmt.Errorf("failed to marshal policy: %w", err)}
+		}
+
+		path := "cage-users-policy.yaml"
+		if err := os.WriteFile(path, out, 0o644); err != nil {
+			return userExportedMsg{err: fmt.Errorf("failed to write policy: %w", err)}
+		}
+
+		return userExportedMsg{path: path}
+	}
+}
+
+func importUsersYAML(path string, current []UserEntry) tea.Cmd {
+	return func() tea.Msg {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return userImportPreviewMsg{err: fmt.Errorf("failed to read %s: %w", path, err)}
+		}
+
+		var doc userPolicyDoc
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return userImportPreviewMsg{err: fmt.Errorf("failed to parse %s: %w", path, err)}
+		}
+
+		currentByID := make(map[string]UserEntry, len(current))
+		for _, u := range current {
+			currentByID[u.UserID] = u
+		}
+
+		var diffs []string
+		users := make([]importedUser, 0, len(doc.Users))
+		for _, u := range doc.Users {
+			existing, ok := currentByID[u.UserID]
+			users = append(users, importedUser{detail: u, creating: !ok})
+			switch {
+			case !ok:
+				diffs = append(diffs, fmt.Sprintf("%s: new user", u.UserID))
+			case existing.Enabled != u.Enabled:
+				diffs = append(diffs, fmt.Sprintf("%s: enabled %v -> %v", u.UserID, existing.Enabled, u.Enabled))
+			default:
+				diffs = append(diffs, fmt.Sprintf("%s: updated", u.UserID))
+			}
+		}
+
+		return userImportPreviewMsg{users: users, diffs: diffs}
+	}
+}
+
+// applyUserImports POSTs/PATCHes every user from a confirmed import
+// preview, so the "i" workflow actually changes server state instead of
+// only showing a diff.
+func (m model) applyUserImports(users []importedUser) tea.Cmd {
+	return func() tea.Msg {
+		for _, u := range users {
+			body, err := json.Marshal(u.detail)
+			if err != nil {
+				return userImportAppliedMsg{err: fmt.Errorf("failed to encode %s: %w", u.detail.UserID, err)}
+			}
+
+			method, path := "PATCH", "/api/v1/admin/users/"+u.detail.UserID
+			if u.creating {
+				method, path = "POST", "/api/v1/admin/users"
+			}
+
+			resp, err := m.doRequest(method, path, bytes.NewReader(body))
+			if err != nil {
+				return userImportAppliedMsg{err: fmt.Errorf("failed to apply %s: %w", u.detail.UserID, err)}
+			}
+			resp.Body.Close()
+		}
+
+		return userImportAppliedMsg{count: len(users)}
+	}
+}
+
+// handleUsersKey processes key presses across the users list, edit,
+// delete-confirm, and import-preview views.
+func (m model) handleUsersKey(msg tea.KeyMsg) (model, tea.Cmd, bool) {
+	switch m.view {
+	case "users":
+		return m.handleUsersListKey(msg)
+	case "user_edit":
+		return m.handleUserEditKey(msg)
+	case "user_delete_confirm":
+		return m.handleUserDeleteKey(msg)
+	case "user_import_preview":
+		return m.handleUserImportKey(msg)
+	}
+	return m, nil, false
+}
+
+func (m model) handleUsersListKey(msg tea.KeyMsg) (model, tea.Cmd, bool) {
+	switch msg.String() {
+	case "n":
+		m.userEdit = newUserEditForm(nil)
+		m.view = "user_edit"
+		return m, textinput.Blink, true
+	case "e":
+		if idx := m.usersTable.Cursor(); idx < len(m.users) {
+			return m, m.fetchUserDetail(m.users[idx].UserID), true
+		}
+	case "d":
+		if idx := m.usersTable.Cursor(); idx < len(m.users) {
+			u := m.users[idx]
+			return m, m.toggleUser(u.UserID, !u.Enabled), true
+		}
+	case "D":
+		if idx := m.usersTable.Cursor(); idx < len(m.users) {
+			m.userDeleteConfirm = newUserDeleteConfirm(m.users[idx].UserID)
+			m.view = "user_delete_confirm"
+			return m, textinput.Blink, true
+		}
+	case "k":
+		if idx := m.usersTable.Cursor(); idx < len(m.users) {
+			return m, m.rotateUserKey(m.users[idx].UserID), true
+		}
+	case "y":
+		return m, m.exportUsersYAML(m.users), true
+	case "i":
+		return m, importUsersYAML("cage-users-policy.yaml", m.users), true
+	}
+	return m, nil, false
+}
+
+func (m model) handleUserEditKey(msg tea.KeyMsg) (model, tea.Cmd, bool) {
+	if m.userEdit == nil {
+		return m, nil, false
+	}
+
+	switch msg.String() {
+	case "esc":
+		m.userEdit = nil
+		m.view = "users"
+		return m, nil, true
+	case "tab", "down":
+		m.userEdit.setFocus(m.userEdit.focus + 1)
+		return m, nil, true
+	case "shift+tab", "up":
+		m.userEdit.setFocus(m.userEdit.focus - 1)
+		return m, nil, true
+	case " ":
+		if m.userEdit.focus == 4 {
+			lang := availableLanguages[m.userEdit.langIdx]
+			m.userEdit.languages[lang] = !m.userEdit.languages[lang]
+			return m, nil, true
+		}
+	case "j":
+		if m.userEdit.focus == 4 {
+			m.userEdit.langIdx = (m.userEdit.langIdx + 1) % len(availableLanguages)
+			return m, nil, true
+		}
+	case "enter":
+		detail := m.userEdit.toDetail()
+		creating := m.userEdit.creating
+		m.userEdit = nil
+		m.view = "users"
+		return m, m.saveUser(detail, creating), true
+	}
+
+	if m.userEdit.focus == 4 {
+		return m, nil, true
+	}
+
+	var cmd tea.Cmd
+	switch m.userEdit.focus {
+	case 0:
+		m.userEdit.userID, cmd = m.userEdit.userID.Update(msg)
+	case 1:
+		m.userEdit.cpu, cmd = m.userEdit.cpu.Update(msg)
+	case 2:
+		m.userEdit.memory, cmd = m.userEdit.memory.Update(msg)
+	case 3:
+		m.userEdit.execQuota, cmd = m.userEdit.execQuota.Update(msg)
+	}
+	return m, cmd, true
+}
+
+func (m model) handleUserDeleteKey(msg tea.KeyMsg) (model, tea.Cmd, bool) {
+	if m.userDeleteConfirm == nil {
+		return m, nil, false
+	}
+
+	switch msg.String() {
+	case "esc":
+		m.userDeleteConfirm = nil
+		m.view = "users"
+		return m, nil, true
+	case "enter":
+		if m.userDeleteConfirm.typed.Value() == m.userDeleteConfirm.userID {
+			userID := m.userDeleteConfirm.userID
+			m.userDeleteConfirm = nil
+			m.view = "users"
+			return m, m.deleteUser(userID), true
+		}
+		return m, nil, true
+	}
+
+	var cmd tea.Cmd
+	m.userDeleteConfirm.typed, cmd = m.userDeleteConfirm.typed.Update(msg)
+	return m, cmd, true
+}
+
+func (m model) handleUserImportKey(msg tea.KeyMsg) (model, tea.Cmd, bool) {
+	if m.userImportPreview == nil {
+		return m, nil, false
+	}
+
+	switch msg.String() {
+	case "esc":
+		m.userImportPreview = nil
+		m.view = "users"
+		return m, nil, true
+	case "enter":
+		users := m.userImportPreview.users
+		m.userImportPreview = nil
+		m.view = "users"
+		return m, m.applyUserImports(users), true
+	}
+	return m, nil, true
+}
+
+func (m *model) updateUsersTable() {
+	rows := make([]table.Row, len(m.users))
+	for i, u := range m.users {
+		enabled := "yes"
+		if !u.Enabled {
+			enabled = "no"
+		}
+		rows[i] = table.Row{
+			u.UserID,
+			enabled,
+			strconv.Itoa(u.Languages),
+			u.LastSeen.Format("2006-01-02 15:04:05"),
+			fmt.Sprintf("%.0f%%", u.QuotaUsedPercent),
+		}
+	}
+	m.usersTable.SetRows(rows)
+}
+
+func (m model) usersView() string {
+	var b strings.Builder
+
+	title := titleStyle.Render(" User Management ")
+	b.WriteString(title)
+	b.WriteString("\n\n")
+
+	if m.loading && len(m.users) == 0 {
+		b.WriteString(m.spinner.View() + " Loading users...")
+	} else {
+		b.WriteString(m.usersTable.View())
+	}
+
+	b.WriteString("\n\n")
+	b.WriteString(helpStyle.Render("n: new  e: edit  d: enable/disable  D: delete  k: rotate key  y: export yaml  i: import yaml  ESC: back  |  p: replays  m: metrics  r: refresh"))
+
+	return b.String()
+}
+
+func (m model) userEditView() string {
+	f := m.userEdit
+	var b strings.Builder
+
+	title := " Edit User "
+	if f.creating {
+		title = " New User "
+	}
+	b.WriteString(titleStyle.Render(title))
+	b.WriteString("\n\n")
+
+	if f.creating {
+		b.WriteString(fmt.Sprintf("User ID:     %s\n", f.userID.View()))
+	} else {
+		b.WriteString(fmt.Sprintf("User ID:     %s\n", f.userID.Value()))
+	}
+	b.WriteString(fmt.Sprintf("CPU quota:   %s\n", f.cpu.View()))
+	b.WriteString(fmt.Sprintf("Memory (MB): %s\n", f.memory.View()))
+	b.WriteString(fmt.Sprintf("Exec quota:  %s\n", f.execQuota.View()))
+
+	b.WriteString("\nLanguages (space to toggle):\n")
+	for i, lang := range availableLanguages {
+		cursor := "  "
+		if f.focus == 4 && f.langIdx == i {
+			cursor = "> "
+		}
+		mark := "[ ]"
+		if f.languages[lang] {
+			mark = "[x]"
+		}
+		b.WriteString(fmt.Sprintf("%s%s %s\n", cursor, mark, lang))
+	}
+
+	b.WriteString("\n")
+	b.WriteString(helpStyle.Render("tab/shift+tab: move  space: toggle language  enter: save  esc: cancel"))
+
+	return b.String()
+}
+
+func (m model) userDeleteConfirmView() string {
+	c := m.userDeleteConfirm
+	var b strings.Builder
+
+	b.WriteString(titleStyle.Render(" Confirm Delete "))
+	b.WriteString("\n\n")
+	b.WriteString(warningStyle.Render(fmt.Sprintf("This will permanently delete user %q.", c.userID)))
+	b.WriteString("\n\n")
+	b.WriteString(fmt.Sprintf("Type the user id to confirm: %s\n\n", c.typed.View()))
+	b.WriteString(helpStyle.Render("enter: confirm  esc: cancel"))
+
+	return b.String()
+}
+
+func (m model) userImportPreviewView() string {
+	p := m.userImportPreview
+	var b strings.Builder
+
+	b.WriteString(titleStyle.Render(" Import Preview "))
+	b.WriteString("\n\n")
+	for _, d := range p.diffs {
+		b.WriteString(d)
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	b.WriteString(helpStyle.Render("enter: apply  esc: cancel"))
+
+	return b.String()
+}
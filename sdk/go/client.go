@@ -3,6 +3,7 @@ package cage
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -11,6 +12,7 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"sync"
 	"time"
 )
 
@@ -19,6 +21,17 @@ type Client struct {
 	BaseURL    string
 	APIKey     string
 	HTTPClient *http.Client
+
+	// UploadChunkSize is the chunk size used by UploadFileChunked when its
+	// UploadOptions.ChunkSize is zero. Defaults to DefaultUploadChunkSize.
+	UploadChunkSize int64
+	// UploadConcurrency is the worker pool size used by UploadFileChunked
+	// when its UploadOptions.Concurrency is zero. Defaults to
+	// DefaultUploadConcurrency.
+	UploadConcurrency int
+
+	deadlineMu sync.Mutex
+	deadline   time.Time
 }
 
 // NewClient creates a new CAGE client
@@ -87,8 +100,38 @@ type FileInfo struct {
 	Permissions string    `json:"permissions,omitempty"`
 }
 
+// SetDeadline stores a deadline on the client. Any call made without an
+// explicit context derives its context from this deadline via
+// context.WithDeadline; a zero Time clears it.
+func (c *Client) SetDeadline(deadline time.Time) {
+	c.deadlineMu.Lock()
+	defer c.deadlineMu.Unlock()
+	c.deadline = deadline
+}
+
+// callContext returns a context for calls that don't take one explicitly,
+// derived from a deadline set via SetDeadline if one is present.
+func (c *Client) callContext() (context.Context, context.CancelFunc) {
+	c.deadlineMu.Lock()
+	deadline := c.deadline
+	c.deadlineMu.Unlock()
+
+	if deadline.IsZero() {
+		return context.Background(), func() {}
+	}
+	return context.WithDeadline(context.Background(), deadline)
+}
+
 // Execute executes code in a sandbox
 func (c *Client) Execute(req *ExecuteRequest) (*ExecuteResponse, error) {
+	ctx, cancel := c.callContext()
+	defer cancel()
+	return c.ExecuteContext(ctx, req)
+}
+
+// ExecuteContext executes code in a sandbox, honoring ctx cancellation and
+// deadlines for the duration of the request.
+func (c *Client) ExecuteContext(ctx context.Context, req *ExecuteRequest) (*ExecuteResponse, error) {
 	if req.Language == "" {
 		req.Language = "python"
 	}
@@ -101,7 +144,7 @@ func (c *Client) Execute(req *ExecuteRequest) (*ExecuteResponse, error) {
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	httpReq, err := http.NewRequest("POST", c.BaseURL+"/api/v1/execute", bytes.NewReader(body))
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", c.BaseURL+"/api/v1/execute", bytes.NewReader(body))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -130,6 +173,14 @@ func (c *Client) Execute(req *ExecuteRequest) (*ExecuteResponse, error) {
 
 // UploadFile uploads a file to the workspace
 func (c *Client) UploadFile(localPath, targetPath string) error {
+	ctx, cancel := c.callContext()
+	defer cancel()
+	return c.UploadFileContext(ctx, localPath, targetPath)
+}
+
+// UploadFileContext uploads a file to the workspace, honoring ctx
+// cancellation and deadlines for the duration of the request.
+func (c *Client) UploadFileContext(ctx context.Context, localPath, targetPath string) error {
 	file, err := os.Open(localPath)
 	if err != nil {
 		return fmt.Errorf("failed to open file: %w", err)
@@ -156,7 +207,7 @@ func (c *Client) UploadFile(localPath, targetPath string) error {
 		return fmt.Errorf("failed to close writer: %w", err)
 	}
 
-	req, err := http.NewRequest("POST", c.BaseURL+"/api/v1/files", body)
+	req, err := http.NewRequestWithContext(ctx, "POST", c.BaseURL+"/api/v1/files", body)
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
 	}
@@ -180,7 +231,15 @@ func (c *Client) UploadFile(localPath, targetPath string) error {
 
 // DownloadFile downloads a file from the workspace
 func (c *Client) DownloadFile(filePath, outputPath string) error {
-	req, err := http.NewRequest("GET", c.BaseURL+"/api/v1/files/"+filePath, nil)
+	ctx, cancel := c.callContext()
+	defer cancel()
+	return c.DownloadFileContext(ctx, filePath, outputPath)
+}
+
+// DownloadFileContext downloads a file from the workspace, honoring ctx
+// cancellation and deadlines for the duration of the request.
+func (c *Client) DownloadFileContext(ctx context.Context, filePath, outputPath string) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.BaseURL+"/api/v1/files/"+filePath, nil)
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
 	}
@@ -213,13 +272,21 @@ func (c *Client) DownloadFile(filePath, outputPath string) error {
 
 // ListFiles lists files in the workspace
 func (c *Client) ListFiles(path string, recursive bool) ([]FileInfo, error) {
+	ctx, cancel := c.callContext()
+	defer cancel()
+	return c.ListFilesContext(ctx, path, recursive)
+}
+
+// ListFilesContext lists files in the workspace, honoring ctx cancellation
+// and deadlines for the duration of the request.
+func (c *Client) ListFilesContext(ctx context.Context, path string, recursive bool) ([]FileInfo, error) {
 	params := url.Values{}
 	params.Set("path", path)
 	if recursive {
 		params.Set("recursive", "true")
 	}
 
-	req, err := http.NewRequest("GET", c.BaseURL+"/api/v1/files?"+params.Encode(), nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", c.BaseURL+"/api/v1/files?"+params.Encode(), nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -250,7 +317,20 @@ func (c *Client) ListFiles(path string, recursive bool) ([]FileInfo, error) {
 
 // Health gets server health status
 func (c *Client) Health() (*HealthResponse, error) {
-	resp, err := http.Get(c.BaseURL + "/health")
+	ctx, cancel := c.callContext()
+	defer cancel()
+	return c.HealthContext(ctx)
+}
+
+// HealthContext gets server health status, honoring ctx cancellation and
+// deadlines for the duration of the request.
+func (c *Client) HealthContext(ctx context.Context) (*HealthResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.BaseURL+"/health", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.HTTPClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("health check failed: %w", err)
 	}
@@ -0,0 +1,167 @@
+package cage
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"nhooyr.io/websocket"
+)
+
+// Session stream tags, framed as a single leading byte on each WebSocket
+// binary message.
+const (
+	sessionStreamStdin  byte = 0
+	sessionStreamStdout byte = 1
+	sessionStreamStderr byte = 2
+	sessionStreamResize byte = 3
+	sessionStreamSignal byte = 4
+	sessionStreamExit   byte = 5
+)
+
+// AttachOptions configures AttachSession.
+type AttachOptions struct {
+	// IdleTimeout closes the session if no frames are exchanged for this
+	// long. Zero disables the idle timeout.
+	IdleTimeout time.Duration
+}
+
+// Session is an attached interactive PTY session. Stdin is written to
+// feed the remote process, Stdout/Stderr are read to consume its output.
+type Session struct {
+	Stdin  io.Writer
+	Stdout io.Reader
+	Stderr io.Reader
+
+	conn     *websocket.Conn
+	exitCode chan int
+
+	stdoutWriter *io.PipeWriter
+	stderrWriter *io.PipeWriter
+
+	closeOnce sync.Once
+}
+
+// AttachSession dials GET /api/v1/sessions/{id}/attach and returns a
+// Session wired to the remote PTY. The API key is re-validated by the
+// server at connect time.
+func (c *Client) AttachSession(ctx context.Context, sessionID string, opts *AttachOptions) (*Session, error) {
+	if opts == nil {
+		opts = &AttachOptions{}
+	}
+
+	wsURL := strings.Replace(c.BaseURL, "http://", "ws://", 1)
+	wsURL = strings.Replace(wsURL, "https://", "wss://", 1)
+	wsURL = fmt.Sprintf("%s/api/v1/sessions/%s/attach", wsURL, sessionID)
+
+	conn, _, err := websocket.Dial(ctx, wsURL, &websocket.DialOptions{
+		HTTPHeader: http.Header{
+			"Authorization": []string{"ApiKey " + c.APIKey},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial session: %w", err)
+	}
+
+	stdoutReader, stdoutWriter := io.Pipe()
+	stderrReader, stderrWriter := io.Pipe()
+
+	s := &Session{
+		Stdout:       stdoutReader,
+		Stderr:       stderrReader,
+		conn:         conn,
+		exitCode:     make(chan int, 1),
+		stdoutWriter: stdoutWriter,
+		stderrWriter: stderrWriter,
+	}
+	s.Stdin = sessionStdinWriter{session: s}
+
+	go s.readLoop(ctx, opts.IdleTimeout)
+
+	return s, nil
+}
+
+func (s *Session) readLoop(ctx context.Context, idleTimeout time.Duration) {
+	defer s.stdoutWriter.Close()
+	defer s.stderrWriter.Close()
+
+	for {
+		readCtx := ctx
+		var cancel context.CancelFunc
+		if idleTimeout > 0 {
+			readCtx, cancel = context.WithTimeout(ctx, idleTimeout)
+		}
+
+		_, data, err := s.conn.Read(readCtx)
+		if cancel != nil {
+			cancel()
+		}
+		if err != nil {
+			s.exitCode <- -1
+			return
+		}
+		if len(data) < 1 {
+			continue
+		}
+
+		tag, payload := data[0], data[1:]
+		switch tag {
+		case sessionStreamStdout:
+			s.stdoutWriter.Write(payload)
+		case sessionStreamStderr:
+			s.stderrWriter.Write(payload)
+		case sessionStreamExit:
+			code := 0
+			if len(payload) >= 4 {
+				code = int(int32(binary.BigEndian.Uint32(payload)))
+			}
+			s.exitCode <- code
+			return
+		}
+	}
+}
+
+// Resize notifies the remote PTY of a terminal window size change.
+func (s *Session) Resize(rows, cols uint16) error {
+	payload := make([]byte, 5)
+	payload[0] = sessionStreamResize
+	binary.BigEndian.PutUint16(payload[1:3], rows)
+	binary.BigEndian.PutUint16(payload[3:5], cols)
+	return s.conn.Write(context.Background(), websocket.MessageBinary, payload)
+}
+
+// Wait blocks until the remote process exits and returns its exit code.
+func (s *Session) Wait() int {
+	return <-s.exitCode
+}
+
+// Close closes the underlying WebSocket connection.
+func (s *Session) Close() error {
+	var err error
+	s.closeOnce.Do(func() {
+		err = s.conn.Close(websocket.StatusNormalClosure, "")
+	})
+	return err
+}
+
+// sessionStdinWriter frames writes to Session.Stdin as tagged WebSocket
+// binary messages.
+type sessionStdinWriter struct {
+	session *Session
+}
+
+func (w sessionStdinWriter) Write(p []byte) (int, error) {
+	payload := make([]byte, 1+len(p))
+	payload[0] = sessionStreamStdin
+	copy(payload[1:], p)
+
+	if err := w.session.conn.Write(context.Background(), websocket.MessageBinary, payload); err != nil {
+		return 0, fmt.Errorf("failed to write stdin: %w", err)
+	}
+	return len(p), nil
+}
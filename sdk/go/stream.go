@@ -0,0 +1,142 @@
+package cage
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Stream frame tags. Each frame on the wire is an 8-byte header followed by
+// a payload of the given length: 1 byte stream tag, 3 reserved bytes (must
+// be zero), and a 4-byte big-endian payload length.
+const (
+	streamFrameStdout        byte = 0
+	streamFrameStderr        byte = 1
+	streamFrameStatus        byte = 2
+	streamFrameResourceUsage byte = 3
+)
+
+const streamFrameHeaderSize = 8
+
+// StreamHandler receives incremental output from Client.ExecuteStream as it
+// arrives, before the execution has finished.
+type StreamHandler interface {
+	OnStdout(data []byte)
+	OnStderr(data []byte)
+	OnResourceUsage(usage ResourceUsage)
+}
+
+// ExecuteStream executes code and streams stdout/stderr/resource_usage
+// frames to handler as they are produced, returning the final
+// ExecuteResponse once the server emits a "status" frame.
+func (c *Client) ExecuteStream(req *ExecuteRequest, handler StreamHandler) (*ExecuteResponse, error) {
+	ctx, cancel := c.callContext()
+	defer cancel()
+	return c.ExecuteStreamContext(ctx, req, handler)
+}
+
+// ExecuteStreamContext is like ExecuteStream but honors ctx for
+// cancellation and deadlines.
+//
+// The server is expected to hijack the connection made to
+// POST /api/v1/execute/stream and keep it open for the lifetime of the
+// execution, writing a sequence of framed events: each frame is an 8-byte
+// header (1 byte stream tag + 3 reserved + 4 byte big-endian length)
+// followed by that many bytes of payload. "stdout" and "stderr" frames
+// carry raw output bytes, "resource_usage" frames carry a JSON-encoded
+// ResourceUsage, and the final "status" frame carries a JSON-encoded
+// ExecuteResponse.
+//
+// The request is issued with a dedicated http.Client that has no
+// Timeout, not Client.HTTPClient: HTTPClient.Timeout covers a request's
+// full lifetime including reading the response body, and would cut off
+// exactly the long-running streamed executions this method exists to
+// support. Callers control duration via ctx or req.TimeoutSeconds
+// instead.
+func (c *Client) ExecuteStreamContext(ctx context.Context, req *ExecuteRequest, handler StreamHandler) (*ExecuteResponse, error) {
+	if req.Language == "" {
+		req.Language = "python"
+	}
+	if req.TimeoutSeconds == 0 {
+		req.TimeoutSeconds = 30
+	}
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", c.BaseURL+"/api/v1/execute/stream", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Authorization", "ApiKey "+c.APIKey)
+
+	streamClient := &http.Client{Transport: c.HTTPClient.Transport}
+	resp, err := streamClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("execution failed (status %d): %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	return readStreamFrames(resp.Body, handler)
+}
+
+func readStreamFrames(r io.Reader, handler StreamHandler) (*ExecuteResponse, error) {
+	header := make([]byte, streamFrameHeaderSize)
+
+	for {
+		if _, err := io.ReadFull(r, header); err != nil {
+			if err == io.EOF {
+				return nil, fmt.Errorf("stream closed before status frame")
+			}
+			return nil, fmt.Errorf("failed to read frame header: %w", err)
+		}
+
+		tag := header[0]
+		length := binary.BigEndian.Uint32(header[4:8])
+
+		payload := make([]byte, length)
+		if _, err := io.ReadFull(r, payload); err != nil {
+			return nil, fmt.Errorf("failed to read frame payload: %w", err)
+		}
+
+		switch tag {
+		case streamFrameStdout:
+			if handler != nil {
+				handler.OnStdout(payload)
+			}
+		case streamFrameStderr:
+			if handler != nil {
+				handler.OnStderr(payload)
+			}
+		case streamFrameResourceUsage:
+			var usage ResourceUsage
+			if err := json.Unmarshal(payload, &usage); err != nil {
+				return nil, fmt.Errorf("failed to decode resource_usage frame: %w", err)
+			}
+			if handler != nil {
+				handler.OnResourceUsage(usage)
+			}
+		case streamFrameStatus:
+			var result ExecuteResponse
+			if err := json.Unmarshal(payload, &result); err != nil {
+				return nil, fmt.Errorf("failed to decode status frame: %w", err)
+			}
+			return &result, nil
+		default:
+			return nil, fmt.Errorf("unknown stream frame tag: %d", tag)
+		}
+	}
+}
@@ -0,0 +1,394 @@
+package cage
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/bmatcuk/doublestar/v4"
+	"golang.org/x/sync/errgroup"
+)
+
+// SyncOptions configures UploadDir and DownloadDir.
+type SyncOptions struct {
+	// Include, if non-empty, restricts syncing to paths matching at least
+	// one of these doublestar glob patterns (relative to the root dir).
+	Include []string
+	// Exclude skips paths matching any of these doublestar glob patterns.
+	Exclude []string
+	// Concurrency is the number of files synced in parallel. Defaults to
+	// DefaultSyncConcurrency.
+	Concurrency int
+	// DeleteExtraneous removes destination files that have no counterpart
+	// in the source tree, mirroring it exactly. Honored by both UploadDir
+	// (deletes remote files absent locally) and DownloadDir (deletes local
+	// files absent remotely).
+	DeleteExtraneous bool
+	// Progress, if set, is called after every file action.
+	Progress func(SyncProgress)
+}
+
+// DefaultSyncConcurrency is used by UploadDir/DownloadDir when
+// SyncOptions.Concurrency is zero.
+const DefaultSyncConcurrency = 8
+
+// SyncProgress reports the running totals of a directory sync in
+// progress.
+type SyncProgress struct {
+	Path        string
+	Action      string // "uploaded", "downloaded", "skipped", "deleted"
+	BytesTotal  int64
+	FilesSynced int
+	FilesTotal  int
+}
+
+// SyncAction records one action taken during a directory sync.
+type SyncAction struct {
+	Path   string `json:"path"`
+	Action string `json:"action"`
+	Bytes  int64  `json:"bytes"`
+}
+
+// SyncReport enumerates every action taken by UploadDir or DownloadDir.
+type SyncReport struct {
+	Actions []SyncAction `json:"actions"`
+}
+
+// manifestEntry describes one file for the server-side diff in
+// POST /api/v1/files/manifest.
+type manifestEntry struct {
+	Path   string `json:"path"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+	Mode   uint32 `json:"mode"`
+}
+
+// UploadDir walks localDir, builds a manifest of {path, size, sha256,
+// mode}, asks the server which paths differ via POST
+// /api/v1/files/manifest, and uploads only those files (via
+// UploadFileChunked) under remoteDir.
+func (c *Client) UploadDir(ctx context.Context, localDir, remoteDir string, opts *SyncOptions) (*SyncReport, error) {
+	if opts == nil {
+		opts = &SyncOptions{}
+	}
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultSyncConcurrency
+	}
+
+	entries, err := walkLocalManifest(localDir, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build manifest: %w", err)
+	}
+
+	toUpload, toDelete, err := c.diffManifest(ctx, remoteDir, entries)
+	if err != nil {
+		return nil, fmt.Errorf("failed to diff manifest: %w", err)
+	}
+
+	report := &SyncReport{}
+	var reportMu sync.Mutex
+	total := len(entries)
+	synced := 0
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(concurrency)
+
+	diffSet := make(map[string]bool, len(toUpload))
+	for _, p := range toUpload {
+		diffSet[p] = true
+	}
+
+	for _, entry := range entries {
+		entry := entry
+		action := "skipped"
+		if diffSet[entry.Path] {
+			action = "uploaded"
+		}
+
+		g.Go(func() error {
+			if action == "uploaded" {
+				localPath := filepath.Join(localDir, entry.Path)
+				remotePath := filepath.ToSlash(filepath.Join(remoteDir, entry.Path))
+				if err := c.UploadFileChunked(gctx, localPath, remotePath, nil); err != nil {
+					return fmt.Errorf("failed to upload %s: %w", entry.Path, err)
+				}
+			}
+
+			reportMu.Lock()
+			synced++
+			report.Actions = append(report.Actions, SyncAction{Path: entry.Path, Action: action, Bytes: entry.Size})
+			current := synced
+			reportMu.Unlock()
+
+			if opts.Progress != nil {
+				opts.Progress(SyncProgress{Path: entry.Path, Action: action, BytesTotal: entry.Size, FilesSynced: current, FilesTotal: total})
+			}
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	if opts.DeleteExtraneous {
+		for _, path := range toDelete {
+			// walkLocalManifest already dropped paths outside
+			// Include/Exclude before diffManifest saw them, so the server
+			// reports them as missing locally even though they're merely
+			// out of scope for this sync. Don't delete those.
+			if !matchesFilters(path, opts) {
+				continue
+			}
+			if err := c.deleteRemoteFile(ctx, filepath.ToSlash(filepath.Join(remoteDir, path))); err != nil {
+				return nil, fmt.Errorf("failed to delete %s: %w", path, err)
+			}
+			report.Actions = append(report.Actions, SyncAction{Path: path, Action: "deleted"})
+			if opts.Progress != nil {
+				opts.Progress(SyncProgress{Path: path, Action: "deleted"})
+			}
+		}
+	}
+
+	return report, nil
+}
+
+// DownloadDir lists files under remoteDir, diffs them against the local
+// manifest of localDir, and downloads only the files that differ.
+func (c *Client) DownloadDir(ctx context.Context, remoteDir, localDir string, opts *SyncOptions) (*SyncReport, error) {
+	if opts == nil {
+		opts = &SyncOptions{}
+	}
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultSyncConcurrency
+	}
+
+	remoteFiles, err := c.ListFilesContext(ctx, remoteDir, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list remote files: %w", err)
+	}
+
+	localEntries, err := walkLocalManifest(localDir, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build local manifest: %w", err)
+	}
+	localDigests := make(map[string]string, len(localEntries))
+	for _, e := range localEntries {
+		localDigests[e.Path] = e.SHA256
+	}
+
+	report := &SyncReport{}
+	var reportMu sync.Mutex
+	total := len(remoteFiles)
+	synced := 0
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(concurrency)
+
+	for _, f := range remoteFiles {
+		f := f
+		if f.Type == "directory" {
+			continue
+		}
+		if !matchesFilters(f.Path, opts) {
+			continue
+		}
+
+		g.Go(func() error {
+			localPath := filepath.Join(localDir, f.Path)
+			action := "downloaded"
+
+			if digest, ok := localDigests[f.Path]; ok {
+				if existingDigest, err := sha256File(localPath); err == nil && existingDigest == digest {
+					action = "skipped"
+				}
+			}
+
+			if action == "downloaded" {
+				if err := os.MkdirAll(filepath.Dir(localPath), 0o755); err != nil {
+					return fmt.Errorf("failed to create directory for %s: %w", f.Path, err)
+				}
+				if err := c.DownloadFileContext(gctx, f.Path, localPath); err != nil {
+					return fmt.Errorf("failed to download %s: %w", f.Path, err)
+				}
+			}
+
+			reportMu.Lock()
+			synced++
+			report.Actions = append(report.Actions, SyncAction{Path: f.Path, Action: action, Bytes: f.SizeBytes})
+			current := synced
+			reportMu.Unlock()
+
+			if opts.Progress != nil {
+				opts.Progress(SyncProgress{Path: f.Path, Action: action, BytesTotal: f.SizeBytes, FilesSynced: current, FilesTotal: total})
+			}
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	if opts.DeleteExtraneous {
+		remotePaths := make(map[string]bool, len(remoteFiles))
+		for _, f := range remoteFiles {
+			if f.Type != "directory" {
+				remotePaths[f.Path] = true
+			}
+		}
+
+		for _, e := range localEntries {
+			if remotePaths[e.Path] {
+				continue
+			}
+			localPath := filepath.Join(localDir, e.Path)
+			if err := os.Remove(localPath); err != nil {
+				return nil, fmt.Errorf("failed to delete %s: %w", e.Path, err)
+			}
+			report.Actions = append(report.Actions, SyncAction{Path: e.Path, Action: "deleted"})
+			if opts.Progress != nil {
+				opts.Progress(SyncProgress{Path: e.Path, Action: "deleted"})
+			}
+		}
+	}
+
+	return report, nil
+}
+
+func walkLocalManifest(localDir string, opts *SyncOptions) ([]manifestEntry, error) {
+	var entries []manifestEntry
+
+	err := filepath.WalkDir(localDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(localDir, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+
+		if !matchesFilters(rel, opts) {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		digest, err := sha256File(path)
+		if err != nil {
+			return err
+		}
+
+		entries = append(entries, manifestEntry{
+			Path:   rel,
+			Size:   info.Size(),
+			SHA256: digest,
+			Mode:   uint32(info.Mode().Perm()),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+func matchesFilters(path string, opts *SyncOptions) bool {
+	if len(opts.Include) > 0 {
+		included := false
+		for _, pattern := range opts.Include {
+			if ok, _ := doublestar.Match(pattern, path); ok {
+				included = true
+				break
+			}
+		}
+		if !included {
+			return false
+		}
+	}
+
+	for _, pattern := range opts.Exclude {
+		if ok, _ := doublestar.Match(pattern, path); ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (c *Client) diffManifest(ctx context.Context, remoteDir string, entries []manifestEntry) (toUpload, toDelete []string, err error) {
+	body, err := json.Marshal(struct {
+		RemoteDir string          `json:"remote_dir"`
+		Files     []manifestEntry `json:"files"`
+	}{RemoteDir: remoteDir, Files: entries})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.BaseURL+"/api/v1/files/manifest", bytes.NewReader(body))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "ApiKey "+c.APIKey)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, nil, fmt.Errorf("manifest diff failed (status %d): %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	var result struct {
+		Differing []string `json:"differing"`
+		Missing   []string `json:"missing_locally"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return result.Differing, result.Missing, nil
+}
+
+func (c *Client) deleteRemoteFile(ctx context.Context, remotePath string) error {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", c.BaseURL+"/api/v1/files/"+remotePath, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "ApiKey "+c.APIKey)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("delete failed (status %d): %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	return nil
+}
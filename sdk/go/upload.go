@@ -0,0 +1,289 @@
+package cage
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// DefaultUploadChunkSize is used by UploadFileChunked when
+// UploadOptions.ChunkSize is zero.
+const DefaultUploadChunkSize = 32 * 1024 * 1024 // 32 MiB
+
+// DefaultUploadConcurrency is used by UploadFileChunked when
+// UploadOptions.Concurrency is zero.
+const DefaultUploadConcurrency = 4
+
+// UploadOptions configures a chunked upload started by UploadFileChunked.
+type UploadOptions struct {
+	// ChunkSize is the size in bytes of each uploaded chunk. Defaults to
+	// DefaultUploadChunkSize.
+	ChunkSize int64
+	// Concurrency is the number of chunks uploaded in parallel. Defaults
+	// to DefaultUploadConcurrency.
+	Concurrency int
+	// Resume, if true, issues a HEAD on the upload's Location to discover
+	// the highest already-uploaded byte offset and skips chunks below it.
+	Resume bool
+	// Progress, if set, is called after every chunk completes with the
+	// cumulative bytes sent and the total file size.
+	Progress func(bytesSent, bytesTotal int64)
+}
+
+// uploadSession is the response to POST /api/v1/files/uploads.
+type uploadSession struct {
+	UploadID string `json:"upload_id"`
+	Location string `json:"location"`
+}
+
+// UploadFileChunked uploads localPath to targetPath using CAGE's chunked
+// upload protocol: a session is opened with POST /api/v1/files/uploads,
+// the file is split into opts.ChunkSize pieces sent concurrently via
+// PATCH Location with a Content-Range header and an echoed SHA-256 of the
+// chunk, and the upload is finalized with PUT Location?digest=sha256:....
+func (c *Client) UploadFileChunked(ctx context.Context, localPath, targetPath string, opts *UploadOptions) error {
+	if opts == nil {
+		opts = &UploadOptions{}
+	}
+	chunkSize := opts.ChunkSize
+	if chunkSize <= 0 {
+		chunkSize = c.UploadChunkSize
+	}
+	if chunkSize <= 0 {
+		chunkSize = DefaultUploadChunkSize
+	}
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = c.UploadConcurrency
+	}
+	if concurrency <= 0 {
+		concurrency = DefaultUploadConcurrency
+	}
+
+	file, err := os.Open(localPath)
+	if err != nil {
+		return fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to stat file: %w", err)
+	}
+	total := info.Size()
+
+	session, err := c.openUploadSession(ctx, targetPath, total)
+	if err != nil {
+		return fmt.Errorf("failed to open upload session: %w", err)
+	}
+
+	startOffset := int64(0)
+	if opts.Resume {
+		startOffset, err = c.headUploadOffset(ctx, session.Location)
+		if err != nil {
+			return fmt.Errorf("failed to resume upload: %w", err)
+		}
+	}
+
+	type chunkRange struct {
+		start, end int64
+	}
+	var ranges []chunkRange
+	for start := startOffset; start < total; start += chunkSize {
+		end := start + chunkSize
+		if end > total {
+			end = total
+		}
+		ranges = append(ranges, chunkRange{start, end})
+	}
+
+	var sent int64 = startOffset
+	var sentMu sync.Mutex
+	var fileMu sync.Mutex
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(concurrency)
+
+	for _, r := range ranges {
+		r := r
+		g.Go(func() error {
+			buf := make([]byte, r.end-r.start)
+
+			fileMu.Lock()
+			_, err := file.ReadAt(buf, r.start)
+			fileMu.Unlock()
+			if err != nil && err != io.EOF {
+				return fmt.Errorf("failed to read chunk at offset %d: %w", r.start, err)
+			}
+
+			sum := sha256.Sum256(buf)
+			digest := hex.EncodeToString(sum[:])
+
+			if err := c.patchUploadChunk(gctx, session.Location, buf, r.start, r.end, total, digest); err != nil {
+				return fmt.Errorf("failed to upload chunk %d-%d: %w", r.start, r.end, err)
+			}
+
+			sentMu.Lock()
+			sent += r.end - r.start
+			current := sent
+			sentMu.Unlock()
+
+			if opts.Progress != nil {
+				opts.Progress(current, total)
+			}
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return err
+	}
+
+	fullDigest, err := sha256File(localPath)
+	if err != nil {
+		return fmt.Errorf("failed to compute file digest: %w", err)
+	}
+
+	return c.commitUpload(ctx, session.Location, fullDigest)
+}
+
+func (c *Client) openUploadSession(ctx context.Context, targetPath string, size int64) (*uploadSession, error) {
+	body, err := json.Marshal(struct {
+		Path string `json:"path"`
+		Size int64  `json:"size"`
+	}{Path: targetPath, Size: size})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.BaseURL+"/api/v1/files/uploads", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "ApiKey "+c.APIKey)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("open session failed (status %d): %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	var session uploadSession
+	if err := json.NewDecoder(resp.Body).Decode(&session); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	if location := resp.Header.Get("Location"); location != "" {
+		session.Location = location
+	}
+
+	return &session, nil
+}
+
+func (c *Client) headUploadOffset(ctx context.Context, location string) (int64, error) {
+	req, err := http.NewRequestWithContext(ctx, "HEAD", c.BaseURL+location, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "ApiKey "+c.APIKey)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return 0, nil
+	}
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		return 0, fmt.Errorf("head offset failed (status %d)", resp.StatusCode)
+	}
+
+	rangeHeader := resp.Header.Get("Range")
+	var offset int64
+	if rangeHeader != "" {
+		if _, err := fmt.Sscanf(rangeHeader, "bytes=0-%d", &offset); err != nil {
+			return 0, nil
+		}
+		return offset + 1, nil
+	}
+	return 0, nil
+}
+
+func (c *Client) patchUploadChunk(ctx context.Context, location string, chunk []byte, start, end, total int64, digest string) error {
+	req, err := http.NewRequestWithContext(ctx, "PATCH", c.BaseURL+location, bytes.NewReader(chunk))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, end-1, total))
+	req.Header.Set("X-Chunk-SHA256", digest)
+	req.Header.Set("Authorization", "ApiKey "+c.APIKey)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusAccepted && resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("chunk rejected (status %d): %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	if echoed := resp.Header.Get("X-Chunk-SHA256"); echoed != "" && echoed != digest {
+		return fmt.Errorf("integrity mismatch: sent %s, server echoed %s", digest, echoed)
+	}
+
+	return nil
+}
+
+func (c *Client) commitUpload(ctx context.Context, location, digest string) error {
+	req, err := http.NewRequestWithContext(ctx, "PUT", c.BaseURL+location+"?digest=sha256:"+digest, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "ApiKey "+c.APIKey)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("commit failed (status %d): %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	return nil
+}
+
+func sha256File(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}